@@ -71,6 +71,119 @@ func (Protocol) EnumDescriptor() ([]byte, []int) {
 	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{0}
 }
 
+type ServerTLS_ClientAuth int32
+
+const (
+	ServerTLS_CLIENT_AUTH_UNSPECIFIED        ServerTLS_ClientAuth = 0
+	ServerTLS_NO_CLIENT_CERT                 ServerTLS_ClientAuth = 1
+	ServerTLS_VERIFY_CLIENT_CERT_IF_GIVEN    ServerTLS_ClientAuth = 2
+	ServerTLS_REQUIRE_AND_VERIFY_CLIENT_CERT ServerTLS_ClientAuth = 3
+)
+
+// Enum value maps for ServerTLS_ClientAuth.
+var (
+	ServerTLS_ClientAuth_name = map[int32]string{
+		0: "CLIENT_AUTH_UNSPECIFIED",
+		1: "NO_CLIENT_CERT",
+		2: "VERIFY_CLIENT_CERT_IF_GIVEN",
+		3: "REQUIRE_AND_VERIFY_CLIENT_CERT",
+	}
+	ServerTLS_ClientAuth_value = map[string]int32{
+		"CLIENT_AUTH_UNSPECIFIED":        0,
+		"NO_CLIENT_CERT":                 1,
+		"VERIFY_CLIENT_CERT_IF_GIVEN":    2,
+		"REQUIRE_AND_VERIFY_CLIENT_CERT": 3,
+	}
+)
+
+func (x ServerTLS_ClientAuth) Enum() *ServerTLS_ClientAuth {
+	p := new(ServerTLS_ClientAuth)
+	*p = x
+	return p
+}
+
+func (x ServerTLS_ClientAuth) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerTLS_ClientAuth) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[1].Descriptor()
+}
+
+func (ServerTLS_ClientAuth) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[1]
+}
+
+func (x ServerTLS_ClientAuth) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerTLS_ClientAuth.Descriptor instead.
+func (ServerTLS_ClientAuth) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type LoadBalancer_Policy int32
+
+const (
+	LoadBalancer_P2C                  LoadBalancer_Policy = 0
+	LoadBalancer_ROUND_ROBIN          LoadBalancer_Policy = 1
+	LoadBalancer_WEIGHTED_ROUND_ROBIN LoadBalancer_Policy = 2
+	LoadBalancer_LEAST_REQUEST        LoadBalancer_Policy = 3
+	LoadBalancer_RANDOM               LoadBalancer_Policy = 4
+	LoadBalancer_RING_HASH            LoadBalancer_Policy = 5
+	LoadBalancer_MAGLEV               LoadBalancer_Policy = 6
+)
+
+// Enum value maps for LoadBalancer_Policy.
+var (
+	LoadBalancer_Policy_name = map[int32]string{
+		0: "P2C",
+		1: "ROUND_ROBIN",
+		2: "WEIGHTED_ROUND_ROBIN",
+		3: "LEAST_REQUEST",
+		4: "RANDOM",
+		5: "RING_HASH",
+		6: "MAGLEV",
+	}
+	LoadBalancer_Policy_value = map[string]int32{
+		"P2C":                  0,
+		"ROUND_ROBIN":          1,
+		"WEIGHTED_ROUND_ROBIN": 2,
+		"LEAST_REQUEST":        3,
+		"RANDOM":               4,
+		"RING_HASH":            5,
+		"MAGLEV":               6,
+	}
+)
+
+func (x LoadBalancer_Policy) Enum() *LoadBalancer_Policy {
+	p := new(LoadBalancer_Policy)
+	*p = x
+	return p
+}
+
+func (x LoadBalancer_Policy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LoadBalancer_Policy) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[2].Descriptor()
+}
+
+func (LoadBalancer_Policy) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[2]
+}
+
+func (x LoadBalancer_Policy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LoadBalancer_Policy.Descriptor instead.
+func (LoadBalancer_Policy) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6, 0}
+}
+
 type Gateway struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -83,6 +196,7 @@ type Gateway struct {
 	Endpoints   []*Endpoint     `protobuf:"bytes,4,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
 	Middlewares []*Middleware   `protobuf:"bytes,5,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
 	TlsStore    map[string]*TLS `protobuf:"bytes,6,rep,name=tls_store,json=tlsStore,proto3" json:"tls_store,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ServerTls   *ServerTLS      `protobuf:"bytes,7,opt,name=server_tls,json=serverTls,proto3" json:"server_tls,omitempty"`
 }
 
 func (x *Gateway) Reset() {
@@ -160,6 +274,98 @@ func (x *Gateway) GetTlsStore() map[string]*TLS {
 	return nil
 }
 
+func (x *Gateway) GetServerTls() *ServerTLS {
+	if x != nil {
+		return x.ServerTls
+	}
+	return nil
+}
+
+// ServerTLS terminates TLS on the listening server with the certificates in tls_store.
+type ServerTLS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the tls_store name of the certificate used when no certificate matches the SNI
+	DefaultTlsConfigName string `protobuf:"bytes,1,opt,name=default_tls_config_name,json=defaultTlsConfigName,proto3" json:"default_tls_config_name,omitempty"`
+	// the minimum TLS version, eg: 1.2, 1.3, default is 1.2
+	MinVersion string `protobuf:"bytes,2,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+	// disable HTTP/2 negotiation via ALPN
+	DisableHttp2 bool `protobuf:"varint,3,opt,name=disable_http2,json=disableHttp2,proto3" json:"disable_http2,omitempty"`
+	// the tls_store name whose cacert verifies the client certificates
+	ClientCaTlsConfigName string `protobuf:"bytes,4,opt,name=client_ca_tls_config_name,json=clientCaTlsConfigName,proto3" json:"client_ca_tls_config_name,omitempty"`
+	// default is VERIFY_CLIENT_CERT_IF_GIVEN when client_ca_tls_config_name is set
+	ClientAuth ServerTLS_ClientAuth `protobuf:"varint,5,opt,name=client_auth,json=clientAuth,proto3,enum=gateway.config.v1.ServerTLS_ClientAuth" json:"client_auth,omitempty"`
+}
+
+func (x *ServerTLS) Reset() {
+	*x = ServerTLS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerTLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerTLS) ProtoMessage() {}
+
+func (x *ServerTLS) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerTLS.ProtoReflect.Descriptor instead.
+func (*ServerTLS) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServerTLS) GetDefaultTlsConfigName() string {
+	if x != nil {
+		return x.DefaultTlsConfigName
+	}
+	return ""
+}
+
+func (x *ServerTLS) GetMinVersion() string {
+	if x != nil {
+		return x.MinVersion
+	}
+	return ""
+}
+
+func (x *ServerTLS) GetDisableHttp2() bool {
+	if x != nil {
+		return x.DisableHttp2
+	}
+	return false
+}
+
+func (x *ServerTLS) GetClientCaTlsConfigName() string {
+	if x != nil {
+		return x.ClientCaTlsConfigName
+	}
+	return ""
+}
+
+func (x *ServerTLS) GetClientAuth() ServerTLS_ClientAuth {
+	if x != nil {
+		return x.ClientAuth
+	}
+	return ServerTLS_CLIENT_AUTH_UNSPECIFIED
+}
+
 type TLS struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -175,7 +381,7 @@ type TLS struct {
 func (x *TLS) Reset() {
 	*x = TLS{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -188,7 +394,7 @@ func (x *TLS) String() string {
 func (*TLS) ProtoMessage() {}
 
 func (x *TLS) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +407,7 @@ func (x *TLS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TLS.ProtoReflect.Descriptor instead.
 func (*TLS) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{1}
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *TLS) GetInsecure() bool {
@@ -252,7 +458,7 @@ type PriorityConfig struct {
 func (x *PriorityConfig) Reset() {
 	*x = PriorityConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -265,7 +471,7 @@ func (x *PriorityConfig) String() string {
 func (*PriorityConfig) ProtoMessage() {}
 
 func (x *PriorityConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +484,7 @@ func (x *PriorityConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PriorityConfig.ProtoReflect.Descriptor instead.
 func (*PriorityConfig) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{2}
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PriorityConfig) GetName() string {
@@ -307,22 +513,32 @@ type Endpoint struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path        string               `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Method      string               `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
-	Description string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Protocol    Protocol             `protobuf:"varint,4,opt,name=protocol,proto3,enum=gateway.config.v1.Protocol" json:"protocol,omitempty"`
-	Timeout     *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Middlewares []*Middleware        `protobuf:"bytes,6,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
-	Backends    []*Backend           `protobuf:"bytes,7,rep,name=backends,proto3" json:"backends,omitempty"`
-	Retry       *Retry               `protobuf:"bytes,8,opt,name=retry,proto3" json:"retry,omitempty"`
-	Metadata    map[string]string    `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Host        string               `protobuf:"bytes,10,opt,name=host,proto3" json:"host,omitempty"`
+	Path             string               `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string               `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Description      string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Protocol         Protocol             `protobuf:"varint,4,opt,name=protocol,proto3,enum=gateway.config.v1.Protocol" json:"protocol,omitempty"`
+	Timeout          *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Middlewares      []*Middleware        `protobuf:"bytes,6,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
+	Backends         []*Backend           `protobuf:"bytes,7,rep,name=backends,proto3" json:"backends,omitempty"`
+	Retry            *Retry               `protobuf:"bytes,8,opt,name=retry,proto3" json:"retry,omitempty"`
+	Metadata         map[string]string    `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Host             string               `protobuf:"bytes,10,opt,name=host,proto3" json:"host,omitempty"`
+	OutlierDetection *OutlierDetection    `protobuf:"bytes,11,opt,name=outlier_detection,json=outlierDetection,proto3" json:"outlier_detection,omitempty"`
+	Streaming        *Streaming           `protobuf:"bytes,12,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	// the tls_store name of the certificate served for the host via SNI
+	TlsConfigName string `protobuf:"bytes,13,opt,name=tls_config_name,json=tlsConfigName,proto3" json:"tls_config_name,omitempty"`
+	// split the traffic by weight across the clusters instead of the backends
+	WeightedClusters *WeightedClusters `protobuf:"bytes,14,opt,name=weighted_clusters,json=weightedClusters,proto3" json:"weighted_clusters,omitempty"`
+	// the load balancing algorithm of the backends, default is p2c
+	LoadBalancer *LoadBalancer `protobuf:"bytes,15,opt,name=load_balancer,json=loadBalancer,proto3" json:"load_balancer,omitempty"`
+	// prefer the nodes in the zone of the gateway, enabled if set
+	Locality *Locality `protobuf:"bytes,16,opt,name=locality,proto3" json:"locality,omitempty"`
 }
 
 func (x *Endpoint) Reset() {
 	*x = Endpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -335,7 +551,7 @@ func (x *Endpoint) String() string {
 func (*Endpoint) ProtoMessage() {}
 
 func (x *Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +564,7 @@ func (x *Endpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
 func (*Endpoint) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{3}
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Endpoint) GetPath() string {
@@ -421,33 +637,80 @@ func (x *Endpoint) GetHost() string {
 	return ""
 }
 
-type Middleware struct {
+func (x *Endpoint) GetOutlierDetection() *OutlierDetection {
+	if x != nil {
+		return x.OutlierDetection
+	}
+	return nil
+}
+
+func (x *Endpoint) GetStreaming() *Streaming {
+	if x != nil {
+		return x.Streaming
+	}
+	return nil
+}
+
+func (x *Endpoint) GetTlsConfigName() string {
+	if x != nil {
+		return x.TlsConfigName
+	}
+	return ""
+}
+
+func (x *Endpoint) GetWeightedClusters() *WeightedClusters {
+	if x != nil {
+		return x.WeightedClusters
+	}
+	return nil
+}
+
+func (x *Endpoint) GetLoadBalancer() *LoadBalancer {
+	if x != nil {
+		return x.LoadBalancer
+	}
+	return nil
+}
+
+func (x *Endpoint) GetLocality() *Locality {
+	if x != nil {
+		return x.Locality
+	}
+	return nil
+}
+
+// Locality reads the zone and region of the nodes from the discovery metadata.
+type Locality struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Options  *anypb.Any `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
-	Required bool       `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	// the metadata key of the zone, default is zone
+	ZoneKey string `protobuf:"bytes,1,opt,name=zone_key,json=zoneKey,proto3" json:"zone_key,omitempty"`
+	// the metadata key of the region, default is region
+	RegionKey string `protobuf:"bytes,2,opt,name=region_key,json=regionKey,proto3" json:"region_key,omitempty"`
+	// the traffic spills over to the region or all the nodes once the healthy
+	// percentage of the local nodes is below it, default is 70
+	MinHealthyPercent uint32 `protobuf:"varint,3,opt,name=min_healthy_percent,json=minHealthyPercent,proto3" json:"min_healthy_percent,omitempty"`
 }
 
-func (x *Middleware) Reset() {
-	*x = Middleware{}
+func (x *Locality) Reset() {
+	*x = Locality{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Middleware) String() string {
+func (x *Locality) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Middleware) ProtoMessage() {}
+func (*Locality) ProtoMessage() {}
 
-func (x *Middleware) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
+func (x *Locality) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,65 +721,63 @@ func (x *Middleware) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Middleware.ProtoReflect.Descriptor instead.
-func (*Middleware) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Locality.ProtoReflect.Descriptor instead.
+func (*Locality) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Middleware) GetName() string {
+func (x *Locality) GetZoneKey() string {
 	if x != nil {
-		return x.Name
+		return x.ZoneKey
 	}
 	return ""
 }
 
-func (x *Middleware) GetOptions() *anypb.Any {
+func (x *Locality) GetRegionKey() string {
 	if x != nil {
-		return x.Options
+		return x.RegionKey
 	}
-	return nil
+	return ""
 }
 
-func (x *Middleware) GetRequired() bool {
+func (x *Locality) GetMinHealthyPercent() uint32 {
 	if x != nil {
-		return x.Required
+		return x.MinHealthyPercent
 	}
-	return false
+	return 0
 }
 
-type Backend struct {
+type LoadBalancer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// localhost
-	// 127.0.0.1:8000
-	// discovery:///service_name
-	Target        string            `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	Weight        *int64            `protobuf:"varint,2,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
-	HealthCheck   *HealthCheck      `protobuf:"bytes,3,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
-	Tls           bool              `protobuf:"varint,4,opt,name=tls,proto3" json:"tls,omitempty"`
-	TlsConfigName string            `protobuf:"bytes,5,opt,name=tls_config_name,json=tlsConfigName,proto3" json:"tls_config_name,omitempty"`
-	Metadata      map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Policy LoadBalancer_Policy `protobuf:"varint,1,opt,name=policy,proto3,enum=gateway.config.v1.LoadBalancer_Policy" json:"policy,omitempty"`
+	// the hash key of RING_HASH and MAGLEV, the first non-empty one is used,
+	// the requests without any key are balanced randomly
+	HashPolicies []*HashPolicy `protobuf:"bytes,2,rep,name=hash_policies,json=hashPolicies,proto3" json:"hash_policies,omitempty"`
+	// the virtual nodes on the ring of the node with the highest weight,
+	// the others are placed in proportion to their weights, default is 160
+	VirtualNodes uint64 `protobuf:"varint,3,opt,name=virtual_nodes,json=virtualNodes,proto3" json:"virtual_nodes,omitempty"`
 }
 
-func (x *Backend) Reset() {
-	*x = Backend{}
+func (x *LoadBalancer) Reset() {
+	*x = LoadBalancer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Backend) String() string {
+func (x *LoadBalancer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Backend) ProtoMessage() {}
+func (*LoadBalancer) ProtoMessage() {}
 
-func (x *Backend) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
+func (x *LoadBalancer) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,76 +788,62 @@ func (x *Backend) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
-func (*Backend) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use LoadBalancer.ProtoReflect.Descriptor instead.
+func (*LoadBalancer) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Backend) GetTarget() string {
+func (x *LoadBalancer) GetPolicy() LoadBalancer_Policy {
 	if x != nil {
-		return x.Target
-	}
-	return ""
-}
-
-func (x *Backend) GetWeight() int64 {
-	if x != nil && x.Weight != nil {
-		return *x.Weight
+		return x.Policy
 	}
-	return 0
+	return LoadBalancer_P2C
 }
 
-func (x *Backend) GetHealthCheck() *HealthCheck {
+func (x *LoadBalancer) GetHashPolicies() []*HashPolicy {
 	if x != nil {
-		return x.HealthCheck
+		return x.HashPolicies
 	}
 	return nil
 }
 
-func (x *Backend) GetTls() bool {
-	if x != nil {
-		return x.Tls
-	}
-	return false
-}
-
-func (x *Backend) GetTlsConfigName() string {
-	if x != nil {
-		return x.TlsConfigName
-	}
-	return ""
-}
-
-func (x *Backend) GetMetadata() map[string]string {
+func (x *LoadBalancer) GetVirtualNodes() uint64 {
 	if x != nil {
-		return x.Metadata
+		return x.VirtualNodes
 	}
-	return nil
+	return 0
 }
 
-type HealthCheck struct {
+type HashPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Key:
+	//
+	//	*HashPolicy_Header
+	//	*HashPolicy_Cookie
+	//	*HashPolicy_SourceIp
+	Key isHashPolicy_Key `protobuf_oneof:"key"`
 }
 
-func (x *HealthCheck) Reset() {
-	*x = HealthCheck{}
+func (x *HashPolicy) Reset() {
+	*x = HashPolicy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HealthCheck) String() string {
+func (x *HashPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheck) ProtoMessage() {}
+func (*HashPolicy) ProtoMessage() {}
 
-func (x *HealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
+func (x *HashPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -607,41 +854,1020 @@ func (x *HealthCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
-func (*HealthCheck) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use HashPolicy.ProtoReflect.Descriptor instead.
+func (*HashPolicy) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{7}
 }
 
-type Retry struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (m *HashPolicy) GetKey() isHashPolicy_Key {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (x *HashPolicy) GetHeader() string {
+	if x, ok := x.GetKey().(*HashPolicy_Header); ok {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *HashPolicy) GetCookie() string {
+	if x, ok := x.GetKey().(*HashPolicy_Cookie); ok {
+		return x.Cookie
+	}
+	return ""
+}
+
+func (x *HashPolicy) GetSourceIp() bool {
+	if x, ok := x.GetKey().(*HashPolicy_SourceIp); ok {
+		return x.SourceIp
+	}
+	return false
+}
+
+type isHashPolicy_Key interface {
+	isHashPolicy_Key()
+}
+
+type HashPolicy_Header struct {
+	Header string `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type HashPolicy_Cookie struct {
+	Cookie string `protobuf:"bytes,2,opt,name=cookie,proto3,oneof"`
+}
+
+type HashPolicy_SourceIp struct {
+	// hash on the client ip of the request
+	SourceIp bool `protobuf:"varint,3,opt,name=source_ip,json=sourceIp,proto3,oneof"`
+}
+
+func (*HashPolicy_Header) isHashPolicy_Key() {}
+
+func (*HashPolicy_Cookie) isHashPolicy_Key() {}
+
+func (*HashPolicy_SourceIp) isHashPolicy_Key() {}
+
+type WeightedClusters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Clusters []*Cluster `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	// the requests with the same value of the header are assigned to the same cluster
+	StickyHeader string `protobuf:"bytes,2,opt,name=sticky_header,json=stickyHeader,proto3" json:"sticky_header,omitempty"`
+	// the requests with the same value of the cookie are assigned to the same cluster
+	StickyCookie string `protobuf:"bytes,3,opt,name=sticky_cookie,json=stickyCookie,proto3" json:"sticky_cookie,omitempty"`
+}
+
+func (x *WeightedClusters) Reset() {
+	*x = WeightedClusters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeightedClusters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeightedClusters) ProtoMessage() {}
+
+func (x *WeightedClusters) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeightedClusters.ProtoReflect.Descriptor instead.
+func (*WeightedClusters) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WeightedClusters) GetClusters() []*Cluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+func (x *WeightedClusters) GetStickyHeader() string {
+	if x != nil {
+		return x.StickyHeader
+	}
+	return ""
+}
+
+func (x *WeightedClusters) GetStickyCookie() string {
+	if x != nil {
+		return x.StickyCookie
+	}
+	return ""
+}
+
+// Cluster is a group of backends with its own picker.
+type Cluster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the cluster label of the metrics
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the relative weight of the traffic, eg: 95
+	Weight   int64      `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Backends []*Backend `protobuf:"bytes,3,rep,name=backends,proto3" json:"backends,omitempty"`
+}
+
+func (x *Cluster) Reset() {
+	*x = Cluster{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cluster) ProtoMessage() {}
+
+func (x *Cluster) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cluster.ProtoReflect.Descriptor instead.
+func (*Cluster) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Cluster) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cluster) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Cluster) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+type Streaming struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// forward request bodies as they arrive instead of reading them entirely
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// the max bytes of request body kept for retries, retries are disabled once exceeded,
+	// default is 65536
+	MaxReplayBytes int64 `protobuf:"varint,2,opt,name=max_replay_bytes,json=maxReplayBytes,proto3" json:"max_replay_bytes,omitempty"`
+}
+
+func (x *Streaming) Reset() {
+	*x = Streaming{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Streaming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Streaming) ProtoMessage() {}
+
+func (x *Streaming) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Streaming.ProtoReflect.Descriptor instead.
+func (*Streaming) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Streaming) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Streaming) GetMaxReplayBytes() int64 {
+	if x != nil {
+		return x.MaxReplayBytes
+	}
+	return 0
+}
+
+type Middleware struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Options  *anypb.Any `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Required bool       `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+}
+
+func (x *Middleware) Reset() {
+	*x = Middleware{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Middleware) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Middleware) ProtoMessage() {}
+
+func (x *Middleware) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Middleware.ProtoReflect.Descriptor instead.
+func (*Middleware) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Middleware) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Middleware) GetOptions() *anypb.Any {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Middleware) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type Backend struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// localhost
+	// 127.0.0.1:8000
+	// discovery:///service_name
+	Target        string            `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Weight        *int64            `protobuf:"varint,2,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
+	HealthCheck   *HealthCheck      `protobuf:"bytes,3,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	Tls           bool              `protobuf:"varint,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	TlsConfigName string            `protobuf:"bytes,5,opt,name=tls_config_name,json=tlsConfigName,proto3" json:"tls_config_name,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// priority group of the backend, should be one of Retry.priorities,
+	// empty means the first priority group
+	Priority string `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *Backend) Reset() {
+	*x = Backend{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Backend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backend) ProtoMessage() {}
+
+func (x *Backend) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
+func (*Backend) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Backend) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Backend) GetWeight() int64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *Backend) GetHealthCheck() *HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+func (x *Backend) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *Backend) GetTlsConfigName() string {
+	if x != nil {
+		return x.TlsConfigName
+	}
+	return ""
+}
+
+func (x *Backend) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Backend) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+type HealthCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Checker:
+	//
+	//	*HealthCheck_Http
+	//	*HealthCheck_Grpc
+	Checker isHealthCheck_Checker `protobuf_oneof:"checker"`
+	// default interval is 10s
+	Interval *durationpb.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	// default timeout is 1s
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// consecutive successes to mark an unhealthy node healthy, default is 1
+	HealthyThreshold uint32 `protobuf:"varint,5,opt,name=healthy_threshold,json=healthyThreshold,proto3" json:"healthy_threshold,omitempty"`
+	// consecutive failures to mark a healthy node unhealthy, default is 3
+	UnhealthyThreshold uint32 `protobuf:"varint,6,opt,name=unhealthy_threshold,json=unhealthyThreshold,proto3" json:"unhealthy_threshold,omitempty"`
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{13}
+}
+
+func (m *HealthCheck) GetChecker() isHealthCheck_Checker {
+	if m != nil {
+		return m.Checker
+	}
+	return nil
+}
+
+func (x *HealthCheck) GetHttp() *HTTPHealthCheck {
+	if x, ok := x.GetChecker().(*HealthCheck_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *HealthCheck) GetGrpc() *GRPCHealthCheck {
+	if x, ok := x.GetChecker().(*HealthCheck_Grpc); ok {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *HealthCheck) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *HealthCheck) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *HealthCheck) GetHealthyThreshold() uint32 {
+	if x != nil {
+		return x.HealthyThreshold
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetUnhealthyThreshold() uint32 {
+	if x != nil {
+		return x.UnhealthyThreshold
+	}
+	return 0
+}
+
+type isHealthCheck_Checker interface {
+	isHealthCheck_Checker()
+}
+
+type HealthCheck_Http struct {
+	Http *HTTPHealthCheck `protobuf:"bytes,1,opt,name=http,proto3,oneof"`
+}
+
+type HealthCheck_Grpc struct {
+	Grpc *GRPCHealthCheck `protobuf:"bytes,2,opt,name=grpc,proto3,oneof"`
+}
+
+func (*HealthCheck_Http) isHealthCheck_Checker() {}
+
+func (*HealthCheck_Grpc) isHealthCheck_Checker() {}
+
+type HTTPHealthCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "/healthz"
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// default method is GET
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Host   string `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	// "200-399", "204", default is "200-399"
+	ExpectedStatuses string `protobuf:"bytes,4,opt,name=expected_statuses,json=expectedStatuses,proto3" json:"expected_statuses,omitempty"`
+}
+
+func (x *HTTPHealthCheck) Reset() {
+	*x = HTTPHealthCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTPHealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPHealthCheck) ProtoMessage() {}
+
+func (x *HTTPHealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPHealthCheck.ProtoReflect.Descriptor instead.
+func (*HTTPHealthCheck) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HTTPHealthCheck) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HTTPHealthCheck) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HTTPHealthCheck) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *HTTPHealthCheck) GetExpectedStatuses() string {
+	if x != nil {
+		return x.ExpectedStatuses
+	}
+	return ""
+}
+
+type GRPCHealthCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the service name in grpc.health.v1.HealthCheckRequest, empty checks the server overall
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+}
+
+func (x *GRPCHealthCheck) Reset() {
+	*x = GRPCHealthCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GRPCHealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCHealthCheck) ProtoMessage() {}
+
+func (x *GRPCHealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCHealthCheck.ProtoReflect.Descriptor instead.
+func (*GRPCHealthCheck) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GRPCHealthCheck) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+type OutlierDetection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// consecutive 5xx responses or local origin failures before ejection, 0 disables it
+	Consecutive_5Xx uint32 `protobuf:"varint,1,opt,name=consecutive_5xx,json=consecutive5xx,proto3" json:"consecutive_5xx,omitempty"`
+	// consecutive 502, 503, 504 responses or local origin failures before ejection, 0 disables it
+	ConsecutiveGatewayFailure uint32 `protobuf:"varint,2,opt,name=consecutive_gateway_failure,json=consecutiveGatewayFailure,proto3" json:"consecutive_gateway_failure,omitempty"`
+	// consecutive connect failures, resets and timeouts before ejection, 0 disables it
+	ConsecutiveLocalOriginFailure uint32 `protobuf:"varint,3,opt,name=consecutive_local_origin_failure,json=consecutiveLocalOriginFailure,proto3" json:"consecutive_local_origin_failure,omitempty"`
+	// the interval to uneject nodes and decay the ejection multiplier, default is 10s
+	Interval *durationpb.Duration `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// the ejection time is base_ejection_time * 2^(ejections - 1), default is 30s
+	BaseEjectionTime *durationpb.Duration `protobuf:"bytes,5,opt,name=base_ejection_time,json=baseEjectionTime,proto3" json:"base_ejection_time,omitempty"`
+	// default max ejection time is 300s
+	MaxEjectionTime *durationpb.Duration `protobuf:"bytes,6,opt,name=max_ejection_time,json=maxEjectionTime,proto3" json:"max_ejection_time,omitempty"`
+	// default max ejection percent is 10
+	MaxEjectionPercent uint32 `protobuf:"varint,7,opt,name=max_ejection_percent,json=maxEjectionPercent,proto3" json:"max_ejection_percent,omitempty"`
+}
+
+func (x *OutlierDetection) Reset() {
+	*x = OutlierDetection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutlierDetection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutlierDetection) ProtoMessage() {}
+
+func (x *OutlierDetection) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutlierDetection.ProtoReflect.Descriptor instead.
+func (*OutlierDetection) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OutlierDetection) GetConsecutive_5Xx() uint32 {
+	if x != nil {
+		return x.Consecutive_5Xx
+	}
+	return 0
+}
+
+func (x *OutlierDetection) GetConsecutiveGatewayFailure() uint32 {
+	if x != nil {
+		return x.ConsecutiveGatewayFailure
+	}
+	return 0
+}
+
+func (x *OutlierDetection) GetConsecutiveLocalOriginFailure() uint32 {
+	if x != nil {
+		return x.ConsecutiveLocalOriginFailure
+	}
+	return 0
+}
+
+func (x *OutlierDetection) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *OutlierDetection) GetBaseEjectionTime() *durationpb.Duration {
+	if x != nil {
+		return x.BaseEjectionTime
+	}
+	return nil
+}
+
+func (x *OutlierDetection) GetMaxEjectionTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxEjectionTime
+	}
+	return nil
+}
+
+func (x *OutlierDetection) GetMaxEjectionPercent() uint32 {
+	if x != nil {
+		return x.MaxEjectionPercent
+	}
+	return 0
+}
+
+type Retry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default attempts is 1
+	Attempts      uint32               `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	PerTryTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=per_try_timeout,json=perTryTimeout,proto3" json:"per_try_timeout,omitempty"`
+	Conditions    []*Condition         `protobuf:"bytes,3,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// primary,secondary
+	// the next priority group is used only after the current group is exhausted or unhealthy
+	Priorities []string `protobuf:"bytes,4,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	// send the parallel attempts to the other nodes instead of waiting for the slow ones
+	Hedging *Hedging `protobuf:"bytes,5,opt,name=hedging,proto3" json:"hedging,omitempty"`
+	// wait between the attempts, the Retry-After and grpc-retry-pushback-ms of the upstream are also honored
+	Backoff *RetryBackoff `protobuf:"bytes,6,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// limit the retries in proportion to the active requests
+	Budget *RetryBudget `protobuf:"bytes,7,opt,name=budget,proto3" json:"budget,omitempty"`
+	// the breaker of the retries, default is success 0.8 and request 10
+	Breaker *RetryBreaker `protobuf:"bytes,8,opt,name=breaker,proto3" json:"breaker,omitempty"`
+}
+
+func (x *Retry) Reset() {
+	*x = Retry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Retry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Retry) ProtoMessage() {}
+
+func (x *Retry) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Retry.ProtoReflect.Descriptor instead.
+func (*Retry) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Retry) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Retry) GetPerTryTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.PerTryTimeout
+	}
+	return nil
+}
+
+func (x *Retry) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *Retry) GetPriorities() []string {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *Retry) GetHedging() *Hedging {
+	if x != nil {
+		return x.Hedging
+	}
+	return nil
+}
+
+func (x *Retry) GetBackoff() *RetryBackoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+func (x *Retry) GetBudget() *RetryBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *Retry) GetBreaker() *RetryBreaker {
+	if x != nil {
+		return x.Breaker
+	}
+	return nil
+}
+
+type RetryBackoff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the interval before the first retry, doubled for each retry, default is 25ms
+	BaseInterval *durationpb.Duration `protobuf:"bytes,1,opt,name=base_interval,json=baseInterval,proto3" json:"base_interval,omitempty"`
+	// default is 10 times of the base interval
+	MaxInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=max_interval,json=maxInterval,proto3" json:"max_interval,omitempty"`
+	// the random ratio of the interval to spread the retries, eg: 0.2 for ±20%
+	Jitter float64 `protobuf:"fixed64,3,opt,name=jitter,proto3" json:"jitter,omitempty"`
+}
+
+func (x *RetryBackoff) Reset() {
+	*x = RetryBackoff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetryBackoff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryBackoff) ProtoMessage() {}
+
+func (x *RetryBackoff) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryBackoff.ProtoReflect.Descriptor instead.
+func (*RetryBackoff) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RetryBackoff) GetBaseInterval() *durationpb.Duration {
+	if x != nil {
+		return x.BaseInterval
+	}
+	return nil
+}
+
+func (x *RetryBackoff) GetMaxInterval() *durationpb.Duration {
+	if x != nil {
+		return x.MaxInterval
+	}
+	return nil
+}
+
+func (x *RetryBackoff) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+type RetryBudget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the max in-flight retries in percentage of the active requests, eg: 20
+	BudgetPercent float64 `protobuf:"fixed64,1,opt,name=budget_percent,json=budgetPercent,proto3" json:"budget_percent,omitempty"`
+	// the retries allowed per second regardless of the percentage, default is 10
+	MinRetriesPerSecond uint32 `protobuf:"varint,2,opt,name=min_retries_per_second,json=minRetriesPerSecond,proto3" json:"min_retries_per_second,omitempty"`
+}
+
+func (x *RetryBudget) Reset() {
+	*x = RetryBudget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetryBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryBudget) ProtoMessage() {}
+
+func (x *RetryBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryBudget.ProtoReflect.Descriptor instead.
+func (*RetryBudget) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RetryBudget) GetBudgetPercent() float64 {
+	if x != nil {
+		return x.BudgetPercent
+	}
+	return 0
+}
+
+func (x *RetryBudget) GetMinRetriesPerSecond() uint32 {
+	if x != nil {
+		return x.MinRetriesPerSecond
+	}
+	return 0
+}
+
+type RetryBreaker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// default attempts is 1
-	Attempts      uint32               `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
-	PerTryTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=per_try_timeout,json=perTryTimeout,proto3" json:"per_try_timeout,omitempty"`
-	Conditions    []*Condition         `protobuf:"bytes,3,rep,name=conditions,proto3" json:"conditions,omitempty"`
-	// primary,secondary
-	Priorities []string `protobuf:"bytes,4,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	// the success ratio of the retries to keep the breaker closed, default is 0.8
+	Success float64 `protobuf:"fixed64,1,opt,name=success,proto3" json:"success,omitempty"`
+	// the min retries in the window before the breaker takes effect, default is 10
+	Request int32 `protobuf:"varint,2,opt,name=request,proto3" json:"request,omitempty"`
+	// the buckets of the window, default is 10
+	Bucket int32 `protobuf:"varint,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// the statistics window, default is 3s
+	Window *durationpb.Duration `protobuf:"bytes,4,opt,name=window,proto3" json:"window,omitempty"`
 }
 
-func (x *Retry) Reset() {
-	*x = Retry{}
+func (x *RetryBreaker) Reset() {
+	*x = RetryBreaker{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[7]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Retry) String() string {
+func (x *RetryBreaker) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Retry) ProtoMessage() {}
+func (*RetryBreaker) ProtoMessage() {}
 
-func (x *Retry) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[7]
+func (x *RetryBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -652,39 +1878,106 @@ func (x *Retry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Retry.ProtoReflect.Descriptor instead.
-func (*Retry) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use RetryBreaker.ProtoReflect.Descriptor instead.
+func (*RetryBreaker) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *Retry) GetAttempts() uint32 {
+func (x *RetryBreaker) GetSuccess() float64 {
 	if x != nil {
-		return x.Attempts
+		return x.Success
 	}
 	return 0
 }
 
-func (x *Retry) GetPerTryTimeout() *durationpb.Duration {
+func (x *RetryBreaker) GetRequest() int32 {
 	if x != nil {
-		return x.PerTryTimeout
+		return x.Request
 	}
-	return nil
+	return 0
 }
 
-func (x *Retry) GetConditions() []*Condition {
+func (x *RetryBreaker) GetBucket() int32 {
 	if x != nil {
-		return x.Conditions
+		return x.Bucket
+	}
+	return 0
+}
+
+func (x *RetryBreaker) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
 	}
 	return nil
 }
 
-func (x *Retry) GetPriorities() []string {
+type Hedging struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the delay of sending the next attempt while the previous ones are still in flight
+	Delay *durationpb.Duration `protobuf:"bytes,1,opt,name=delay,proto3" json:"delay,omitempty"`
+	// the max attempts including the first one, the failed attempts are retried
+	// at once within it instead of Retry.attempts, default is 2
+	MaxAttempts uint32 `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// also hedge the non-idempotent methods, eg: POST of the unary gRPC calls
+	NonIdempotent bool `protobuf:"varint,3,opt,name=non_idempotent,json=nonIdempotent,proto3" json:"non_idempotent,omitempty"`
+}
+
+func (x *Hedging) Reset() {
+	*x = Hedging{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hedging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hedging) ProtoMessage() {}
+
+func (x *Hedging) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hedging.ProtoReflect.Descriptor instead.
+func (*Hedging) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Hedging) GetDelay() *durationpb.Duration {
 	if x != nil {
-		return x.Priorities
+		return x.Delay
 	}
 	return nil
 }
 
+func (x *Hedging) GetMaxAttempts() uint32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Hedging) GetNonIdempotent() bool {
+	if x != nil {
+		return x.NonIdempotent
+	}
+	return false
+}
+
 type Condition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -694,26 +1987,260 @@ type Condition struct {
 	//
 	//	*Condition_ByStatusCode
 	//	*Condition_ByHeader
+	//	*Condition_ByGrpcCode
+	//	*Condition_ByError
+	//	*Condition_ByMethod
+	//	*Condition_ByBody
+	//	*Condition_AllOf
+	//	*Condition_Not
 	Condition isCondition_Condition `protobuf_oneof:"condition"`
 }
 
-func (x *Condition) Reset() {
-	*x = Condition{}
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{22}
+}
+
+func (m *Condition) GetCondition() isCondition_Condition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+func (x *Condition) GetByStatusCode() string {
+	if x, ok := x.GetCondition().(*Condition_ByStatusCode); ok {
+		return x.ByStatusCode
+	}
+	return ""
+}
+
+func (x *Condition) GetByHeader() *ConditionHeader {
+	if x, ok := x.GetCondition().(*Condition_ByHeader); ok {
+		return x.ByHeader
+	}
+	return nil
+}
+
+func (x *Condition) GetByGrpcCode() string {
+	if x, ok := x.GetCondition().(*Condition_ByGrpcCode); ok {
+		return x.ByGrpcCode
+	}
+	return ""
+}
+
+func (x *Condition) GetByError() string {
+	if x, ok := x.GetCondition().(*Condition_ByError); ok {
+		return x.ByError
+	}
+	return ""
+}
+
+func (x *Condition) GetByMethod() string {
+	if x, ok := x.GetCondition().(*Condition_ByMethod); ok {
+		return x.ByMethod
+	}
+	return ""
+}
+
+func (x *Condition) GetByBody() *ConditionBody {
+	if x, ok := x.GetCondition().(*Condition_ByBody); ok {
+		return x.ByBody
+	}
+	return nil
+}
+
+func (x *Condition) GetAllOf() *ConditionConditions {
+	if x, ok := x.GetCondition().(*Condition_AllOf); ok {
+		return x.AllOf
+	}
+	return nil
+}
+
+func (x *Condition) GetNot() *Condition {
+	if x, ok := x.GetCondition().(*Condition_Not); ok {
+		return x.Not
+	}
+	return nil
+}
+
+type isCondition_Condition interface {
+	isCondition_Condition()
+}
+
+type Condition_ByStatusCode struct {
+	// "500-599", "429"
+	ByStatusCode string `protobuf:"bytes,1,opt,name=by_status_code,json=byStatusCode,proto3,oneof"`
+}
+
+type Condition_ByHeader struct {
+	// {"name": "grpc-status", "value": "14"}
+	ByHeader *ConditionHeader `protobuf:"bytes,2,opt,name=by_header,json=byHeader,proto3,oneof"`
+}
+
+type Condition_ByGrpcCode struct {
+	// the grpc status code of the headers or trailers by the name or number,
+	// eg: "UNAVAILABLE", "14", "1-4", ["UNAVAILABLE", "RESOURCE_EXHAUSTED"].
+	// the trailers are read only for the unary responses of the Content-Length
+	// up to 64KB, the condition is not matched for the other responses
+	ByGrpcCode string `protobuf:"bytes,3,opt,name=by_grpc_code,json=byGrpcCode,proto3,oneof"`
+}
+
+type Condition_ByError struct {
+	// the class of the transport error: connect_refused, reset, timeout, tls, canceled or any,
+	// eg: "reset", ["connect_refused", "reset"]
+	ByError string `protobuf:"bytes,4,opt,name=by_error,json=byError,proto3,oneof"`
+}
+
+type Condition_ByMethod struct {
+	// the method of the request, compose it with all_of, eg: ["GET", "HEAD"]
+	ByMethod string `protobuf:"bytes,5,opt,name=by_method,json=byMethod,proto3,oneof"`
+}
+
+type Condition_ByBody struct {
+	ByBody *ConditionBody `protobuf:"bytes,6,opt,name=by_body,json=byBody,proto3,oneof"`
+}
+
+type Condition_AllOf struct {
+	// all the conditions are matched
+	AllOf *ConditionConditions `protobuf:"bytes,7,opt,name=all_of,json=allOf,proto3,oneof"`
+}
+
+type Condition_Not struct {
+	// the condition is not matched
+	Not *Condition `protobuf:"bytes,8,opt,name=not,proto3,oneof"`
+}
+
+func (*Condition_ByStatusCode) isCondition_Condition() {}
+
+func (*Condition_ByHeader) isCondition_Condition() {}
+
+func (*Condition_ByGrpcCode) isCondition_Condition() {}
+
+func (*Condition_ByError) isCondition_Condition() {}
+
+func (*Condition_ByMethod) isCondition_Condition() {}
+
+func (*Condition_ByBody) isCondition_Condition() {}
+
+func (*Condition_AllOf) isCondition_Condition() {}
+
+func (*Condition_Not) isCondition_Condition() {}
+
+type ConditionHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ConditionHeader) Reset() {
+	*x = ConditionHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConditionHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionHeader) ProtoMessage() {}
+
+func (x *ConditionHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionHeader.ProtoReflect.Descriptor instead.
+func (*ConditionHeader) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{22, 0}
+}
+
+func (x *ConditionHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConditionHeader) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ConditionBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// match the whole body or the value of the json path by the regular expression
+	Regex string `protobuf:"bytes,1,opt,name=regex,proto3" json:"regex,omitempty"`
+	// the dot separated path of the json body, eg: "error.code" or "items.0.id"
+	JsonPath string `protobuf:"bytes,2,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
+	// the exact value of the json path, the json path is matched if it exists without regex and value
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// the body larger than it is not matched, default is 65536
+	MaxBodySize int64 `protobuf:"varint,4,opt,name=max_body_size,json=maxBodySize,proto3" json:"max_body_size,omitempty"`
+}
+
+func (x *ConditionBody) Reset() {
+	*x = ConditionBody{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Condition) String() string {
+func (x *ConditionBody) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Condition) ProtoMessage() {}
+func (*ConditionBody) ProtoMessage() {}
 
-func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+func (x *ConditionBody) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,76 +2251,64 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
-func (*Condition) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ConditionBody.ProtoReflect.Descriptor instead.
+func (*ConditionBody) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{22, 1}
 }
 
-func (m *Condition) GetCondition() isCondition_Condition {
-	if m != nil {
-		return m.Condition
+func (x *ConditionBody) GetRegex() string {
+	if x != nil {
+		return x.Regex
 	}
-	return nil
+	return ""
 }
 
-func (x *Condition) GetByStatusCode() string {
-	if x, ok := x.GetCondition().(*Condition_ByStatusCode); ok {
-		return x.ByStatusCode
+func (x *ConditionBody) GetJsonPath() string {
+	if x != nil {
+		return x.JsonPath
 	}
 	return ""
 }
 
-func (x *Condition) GetByHeader() *ConditionHeader {
-	if x, ok := x.GetCondition().(*Condition_ByHeader); ok {
-		return x.ByHeader
+func (x *ConditionBody) GetValue() string {
+	if x != nil {
+		return x.Value
 	}
-	return nil
-}
-
-type isCondition_Condition interface {
-	isCondition_Condition()
-}
-
-type Condition_ByStatusCode struct {
-	// "500-599", "429"
-	ByStatusCode string `protobuf:"bytes,1,opt,name=by_status_code,json=byStatusCode,proto3,oneof"`
+	return ""
 }
 
-type Condition_ByHeader struct {
-	// {"name": "grpc-status", "value": "14"}
-	ByHeader *ConditionHeader `protobuf:"bytes,2,opt,name=by_header,json=byHeader,proto3,oneof"`
+func (x *ConditionBody) GetMaxBodySize() int64 {
+	if x != nil {
+		return x.MaxBodySize
+	}
+	return 0
 }
 
-func (*Condition_ByStatusCode) isCondition_Condition() {}
-
-func (*Condition_ByHeader) isCondition_Condition() {}
-
-type ConditionHeader struct {
+type ConditionConditions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Conditions []*Condition `protobuf:"bytes,1,rep,name=conditions,proto3" json:"conditions,omitempty"`
 }
 
-func (x *ConditionHeader) Reset() {
-	*x = ConditionHeader{}
+func (x *ConditionConditions) Reset() {
+	*x = ConditionConditions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[12]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConditionHeader) String() string {
+func (x *ConditionConditions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConditionHeader) ProtoMessage() {}
+func (*ConditionConditions) ProtoMessage() {}
 
-func (x *ConditionHeader) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[12]
+func (x *ConditionConditions) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,23 +2319,16 @@ func (x *ConditionHeader) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConditionHeader.ProtoReflect.Descriptor instead.
-func (*ConditionHeader) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{8, 0}
-}
-
-func (x *ConditionHeader) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use ConditionConditions.ProtoReflect.Descriptor instead.
+func (*ConditionConditions) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{22, 2}
 }
 
-func (x *ConditionHeader) GetValue() string {
+func (x *ConditionConditions) GetConditions() []*Condition {
 	if x != nil {
-		return x.Value
+		return x.Conditions
 	}
-	return ""
+	return nil
 }
 
 var File_gateway_config_v1_gateway_proto protoreflect.FileDescriptor
@@ -833,7 +2341,7 @@ var file_gateway_config_v1_gateway_proto_rawDesc = []byte{
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xe9, 0x02, 0x0a, 0x07, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0xa6, 0x03, 0x0a, 0x07, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
 	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x05, 0x68, 0x6f, 0x73,
@@ -850,120 +2358,367 @@ var file_gateway_config_v1_gateway_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x54,
 	0x6c, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x74, 0x6c,
-	0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x1a, 0x53, 0x0a, 0x0d, 0x54, 0x6c, 0x73, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x4c, 0x53,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x80, 0x01, 0x0a, 0x03,
-	0x54, 0x4c, 0x53, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x65, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x63, 0x61, 0x63, 0x65, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a,
-	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x79,
-	0x0a, 0x0e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x5f, 0x74, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x4c, 0x53, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x54, 0x6c, 0x73, 0x1a, 0x53, 0x0a, 0x0d, 0x54, 0x6c, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x4c, 0x53, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x91, 0x03, 0x0a, 0x09, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x54, 0x4c, 0x53, 0x12, 0x35, 0x0a, 0x17, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x32, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x74,
+	0x74, 0x70, 0x32, 0x12, 0x38, 0x0a, 0x19, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x61,
+	0x5f, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x61,
+	0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x48, 0x0a,
+	0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x4c, 0x53,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x52, 0x0a, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x22, 0x82, 0x01, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54,
+	0x5f, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54,
+	0x5f, 0x43, 0x45, 0x52, 0x54, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x56, 0x45, 0x52, 0x49, 0x46,
+	0x59, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x5f, 0x49, 0x46,
+	0x5f, 0x47, 0x49, 0x56, 0x45, 0x4e, 0x10, 0x02, 0x12, 0x22, 0x0a, 0x1e, 0x52, 0x45, 0x51, 0x55,
+	0x49, 0x52, 0x45, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x59, 0x5f, 0x43,
+	0x4c, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x45, 0x52, 0x54, 0x10, 0x03, 0x22, 0x80, 0x01, 0x0a,
+	0x03, 0x54, 0x4c, 0x53, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x65, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x61, 0x63, 0x65, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22,
+	0x79, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x39, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x8e, 0x07, 0x0a, 0x08, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x33,
+	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x64,
+	0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x52, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x05,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x45, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x6c, 0x69,
+	0x65, 0x72, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x65, 0x72, 0x44, 0x65,
+	0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x6c, 0x69, 0x65, 0x72,
+	0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x09, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a,
+	0x11, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x52, 0x10, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x44, 0x0a, 0x0d, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x52, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
+	0x6c, 0x69, 0x74, 0x79, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x74, 0x0a, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x7a, 0x6f, 0x6e, 0x65, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x4b,
+	0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x4b, 0x65,
+	0x79, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11,
+	0x6d, 0x69, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x22, 0xaf, 0x02, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x72, 0x12, 0x3e, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x42, 0x0a, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61,
+	0x73, 0x68, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0c, 0x68, 0x61, 0x73, 0x68, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61,
+	0x6c, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x76,
+	0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x76, 0x0a, 0x06, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x32, 0x43, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x4f, 0x42, 0x49, 0x4e, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x57, 0x45, 0x49, 0x47, 0x48, 0x54, 0x45, 0x44, 0x5f, 0x52, 0x4f, 0x55, 0x4e,
+	0x44, 0x5f, 0x52, 0x4f, 0x42, 0x49, 0x4e, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x41,
+	0x53, 0x54, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x49, 0x4e, 0x47,
+	0x5f, 0x48, 0x41, 0x53, 0x48, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x47, 0x4c, 0x45,
+	0x56, 0x10, 0x06, 0x22, 0x66, 0x0a, 0x0a, 0x48, 0x61, 0x73, 0x68, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x18, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x06, 0x63,
+	0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x63,
+	0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x12, 0x1d, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x49, 0x70, 0x42, 0x05, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x94, 0x01, 0x0a, 0x10,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x36, 0x0a, 0x08, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x08,
+	0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x69, 0x63,
+	0x6b, 0x79, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x79, 0x5f, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x69, 0x63, 0x6b, 0x79, 0x43, 0x6f, 0x6f, 0x6b,
+	0x69, 0x65, 0x22, 0x6d, 0x0a, 0x07, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x73, 0x22, 0x4f, 0x0a, 0x09, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x18,
+	0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x22, 0x6c, 0x0a, 0x0a, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65,
 	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39,
-	0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09,
-	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x87, 0x04, 0x0a, 0x08, 0x45, 0x6e,
-	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x33, 0x0a,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x22, 0xe5, 0x02, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x41, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x44,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xd6, 0x02, 0x0a, 0x0b, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x38, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74,
+	0x74, 0x70, 0x12, 0x38, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x67, 0x72, 0x70, 0x63, 0x12, 0x35, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x12, 0x3f, 0x0a, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x64, 0x64,
-	0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x52, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
-	0x72, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x18,
-	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
-	0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x72,
-	0x65, 0x74, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x45, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x10, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x75, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x12, 0x75, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x72, 0x22, 0x7e, 0x0a, 0x0f, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65,
+	0x73, 0x22, 0x2b, 0x0a, 0x0f, 0x47, 0x52, 0x50, 0x43, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xbd,
+	0x03, 0x0a, 0x10, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x65, 0x72, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x35, 0x78, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x35, 0x78, 0x78, 0x12, 0x3e, 0x0a, 0x1b,
+	0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x19, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x47, 0x0a, 0x20,
+	0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1d, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x76, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x47, 0x0a, 0x12,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x10, 0x62, 0x61, 0x73, 0x65, 0x45, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x6d, 0x61, 0x78,
+	0x45, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x14,
+	0x6d, 0x61, 0x78, 0x5f, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x45,
+	0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0xa8,
+	0x03, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x79, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x54, 0x72, 0x79,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x68, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x64, 0x67, 0x69,
+	0x6e, 0x67, 0x52, 0x07, 0x68, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x39, 0x0a, 0x07, 0x62,
+	0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x52, 0x07, 0x62,
+	0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x36, 0x0a, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x39,
+	0x0a, 0x07, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72,
+	0x52, 0x07, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x22, 0xa4, 0x01, 0x0a, 0x0c, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x3e, 0x0a, 0x0d, 0x62, 0x61,
+	0x73, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x62, 0x61,
+	0x73, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x3c, 0x0a, 0x0c, 0x6d, 0x61,
+	0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72,
+	0x22, 0x69, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x0c,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x84, 0x01, 0x0a, 0x07,
+	0x48, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x2f, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f,
+	0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6e,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x6e, 0x6f, 0x6e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x74, 0x22, 0x8b, 0x05, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x26, 0x0a, 0x0e, 0x62, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c,
+	0x62, 0x79, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x79, 0x47, 0x72, 0x70, 0x63, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x1b, 0x0a, 0x08, 0x62, 0x79, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x62, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a,
+	0x09, 0x62, 0x79, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x3c, 0x0a, 0x07,
+	0x62, 0x79, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
 	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
-	0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0x6c, 0x0a, 0x0a, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x64, 0x22, 0xc9, 0x02, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88,
-	0x01, 0x01, 0x12, 0x41, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x63, 0x68, 0x65,
-	0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61,
-	0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x44, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x28, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x0d, 0x0a,
-	0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xc4, 0x01, 0x0a,
-	0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
-	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
-	0x74, 0x73, 0x12, 0x41, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x79, 0x5f, 0x74, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x54, 0x72, 0x79, 0x54, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x62, 0x6f, 0x64, 0x79,
+	0x48, 0x00, 0x52, 0x06, 0x62, 0x79, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x40, 0x0a, 0x06, 0x61, 0x6c,
+	0x6c, 0x5f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x4f, 0x66, 0x12, 0x30, 0x0a, 0x03,
+	0x6e, 0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x1a, 0x32,
+	0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x1a, 0x73, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65,
+	0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78,
+	0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42,
+	0x6f, 0x64, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0x4a, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65,
 	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
 	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x79, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x62, 0x79, 0x5f,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67,
-	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x1a, 0x32, 0x0a,
-	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x42, 0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x2f,
-	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48,
-	0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10, 0x02, 0x42,
-	0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
-	0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x2a, 0x2f, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x0f, 0x0a, 0x0b,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10,
+	0x02, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -978,49 +2733,97 @@ func file_gateway_config_v1_gateway_proto_rawDescGZIP() []byte {
 	return file_gateway_config_v1_gateway_proto_rawDescData
 }
 
-var file_gateway_config_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_gateway_config_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_gateway_config_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_gateway_config_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_gateway_config_v1_gateway_proto_goTypes = []interface{}{
 	(Protocol)(0),               // 0: gateway.config.v1.Protocol
-	(*Gateway)(nil),             // 1: gateway.config.v1.Gateway
-	(*TLS)(nil),                 // 2: gateway.config.v1.TLS
-	(*PriorityConfig)(nil),      // 3: gateway.config.v1.PriorityConfig
-	(*Endpoint)(nil),            // 4: gateway.config.v1.Endpoint
-	(*Middleware)(nil),          // 5: gateway.config.v1.Middleware
-	(*Backend)(nil),             // 6: gateway.config.v1.Backend
-	(*HealthCheck)(nil),         // 7: gateway.config.v1.HealthCheck
-	(*Retry)(nil),               // 8: gateway.config.v1.Retry
-	(*Condition)(nil),           // 9: gateway.config.v1.Condition
-	nil,                         // 10: gateway.config.v1.Gateway.TlsStoreEntry
-	nil,                         // 11: gateway.config.v1.Endpoint.MetadataEntry
-	nil,                         // 12: gateway.config.v1.Backend.MetadataEntry
-	(*ConditionHeader)(nil),     // 13: gateway.config.v1.Condition.header
-	(*durationpb.Duration)(nil), // 14: google.protobuf.Duration
-	(*anypb.Any)(nil),           // 15: google.protobuf.Any
+	(ServerTLS_ClientAuth)(0),   // 1: gateway.config.v1.ServerTLS.ClientAuth
+	(LoadBalancer_Policy)(0),    // 2: gateway.config.v1.LoadBalancer.Policy
+	(*Gateway)(nil),             // 3: gateway.config.v1.Gateway
+	(*ServerTLS)(nil),           // 4: gateway.config.v1.ServerTLS
+	(*TLS)(nil),                 // 5: gateway.config.v1.TLS
+	(*PriorityConfig)(nil),      // 6: gateway.config.v1.PriorityConfig
+	(*Endpoint)(nil),            // 7: gateway.config.v1.Endpoint
+	(*Locality)(nil),            // 8: gateway.config.v1.Locality
+	(*LoadBalancer)(nil),        // 9: gateway.config.v1.LoadBalancer
+	(*HashPolicy)(nil),          // 10: gateway.config.v1.HashPolicy
+	(*WeightedClusters)(nil),    // 11: gateway.config.v1.WeightedClusters
+	(*Cluster)(nil),             // 12: gateway.config.v1.Cluster
+	(*Streaming)(nil),           // 13: gateway.config.v1.Streaming
+	(*Middleware)(nil),          // 14: gateway.config.v1.Middleware
+	(*Backend)(nil),             // 15: gateway.config.v1.Backend
+	(*HealthCheck)(nil),         // 16: gateway.config.v1.HealthCheck
+	(*HTTPHealthCheck)(nil),     // 17: gateway.config.v1.HTTPHealthCheck
+	(*GRPCHealthCheck)(nil),     // 18: gateway.config.v1.GRPCHealthCheck
+	(*OutlierDetection)(nil),    // 19: gateway.config.v1.OutlierDetection
+	(*Retry)(nil),               // 20: gateway.config.v1.Retry
+	(*RetryBackoff)(nil),        // 21: gateway.config.v1.RetryBackoff
+	(*RetryBudget)(nil),         // 22: gateway.config.v1.RetryBudget
+	(*RetryBreaker)(nil),        // 23: gateway.config.v1.RetryBreaker
+	(*Hedging)(nil),             // 24: gateway.config.v1.Hedging
+	(*Condition)(nil),           // 25: gateway.config.v1.Condition
+	nil,                         // 26: gateway.config.v1.Gateway.TlsStoreEntry
+	nil,                         // 27: gateway.config.v1.Endpoint.MetadataEntry
+	nil,                         // 28: gateway.config.v1.Backend.MetadataEntry
+	(*ConditionHeader)(nil),     // 29: gateway.config.v1.Condition.header
+	(*ConditionBody)(nil),       // 30: gateway.config.v1.Condition.body
+	(*ConditionConditions)(nil), // 31: gateway.config.v1.Condition.conditions
+	(*durationpb.Duration)(nil), // 32: google.protobuf.Duration
+	(*anypb.Any)(nil),           // 33: google.protobuf.Any
 }
 var file_gateway_config_v1_gateway_proto_depIdxs = []int32{
-	4,  // 0: gateway.config.v1.Gateway.endpoints:type_name -> gateway.config.v1.Endpoint
-	5,  // 1: gateway.config.v1.Gateway.middlewares:type_name -> gateway.config.v1.Middleware
-	10, // 2: gateway.config.v1.Gateway.tls_store:type_name -> gateway.config.v1.Gateway.TlsStoreEntry
-	4,  // 3: gateway.config.v1.PriorityConfig.endpoints:type_name -> gateway.config.v1.Endpoint
-	0,  // 4: gateway.config.v1.Endpoint.protocol:type_name -> gateway.config.v1.Protocol
-	14, // 5: gateway.config.v1.Endpoint.timeout:type_name -> google.protobuf.Duration
-	5,  // 6: gateway.config.v1.Endpoint.middlewares:type_name -> gateway.config.v1.Middleware
-	6,  // 7: gateway.config.v1.Endpoint.backends:type_name -> gateway.config.v1.Backend
-	8,  // 8: gateway.config.v1.Endpoint.retry:type_name -> gateway.config.v1.Retry
-	11, // 9: gateway.config.v1.Endpoint.metadata:type_name -> gateway.config.v1.Endpoint.MetadataEntry
-	15, // 10: gateway.config.v1.Middleware.options:type_name -> google.protobuf.Any
-	7,  // 11: gateway.config.v1.Backend.health_check:type_name -> gateway.config.v1.HealthCheck
-	12, // 12: gateway.config.v1.Backend.metadata:type_name -> gateway.config.v1.Backend.MetadataEntry
-	14, // 13: gateway.config.v1.Retry.per_try_timeout:type_name -> google.protobuf.Duration
-	9,  // 14: gateway.config.v1.Retry.conditions:type_name -> gateway.config.v1.Condition
-	13, // 15: gateway.config.v1.Condition.by_header:type_name -> gateway.config.v1.Condition.header
-	2,  // 16: gateway.config.v1.Gateway.TlsStoreEntry.value:type_name -> gateway.config.v1.TLS
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	7,  // 0: gateway.config.v1.Gateway.endpoints:type_name -> gateway.config.v1.Endpoint
+	14, // 1: gateway.config.v1.Gateway.middlewares:type_name -> gateway.config.v1.Middleware
+	26, // 2: gateway.config.v1.Gateway.tls_store:type_name -> gateway.config.v1.Gateway.TlsStoreEntry
+	4,  // 3: gateway.config.v1.Gateway.server_tls:type_name -> gateway.config.v1.ServerTLS
+	1,  // 4: gateway.config.v1.ServerTLS.client_auth:type_name -> gateway.config.v1.ServerTLS.ClientAuth
+	7,  // 5: gateway.config.v1.PriorityConfig.endpoints:type_name -> gateway.config.v1.Endpoint
+	0,  // 6: gateway.config.v1.Endpoint.protocol:type_name -> gateway.config.v1.Protocol
+	32, // 7: gateway.config.v1.Endpoint.timeout:type_name -> google.protobuf.Duration
+	14, // 8: gateway.config.v1.Endpoint.middlewares:type_name -> gateway.config.v1.Middleware
+	15, // 9: gateway.config.v1.Endpoint.backends:type_name -> gateway.config.v1.Backend
+	20, // 10: gateway.config.v1.Endpoint.retry:type_name -> gateway.config.v1.Retry
+	27, // 11: gateway.config.v1.Endpoint.metadata:type_name -> gateway.config.v1.Endpoint.MetadataEntry
+	19, // 12: gateway.config.v1.Endpoint.outlier_detection:type_name -> gateway.config.v1.OutlierDetection
+	13, // 13: gateway.config.v1.Endpoint.streaming:type_name -> gateway.config.v1.Streaming
+	11, // 14: gateway.config.v1.Endpoint.weighted_clusters:type_name -> gateway.config.v1.WeightedClusters
+	9,  // 15: gateway.config.v1.Endpoint.load_balancer:type_name -> gateway.config.v1.LoadBalancer
+	8,  // 16: gateway.config.v1.Endpoint.locality:type_name -> gateway.config.v1.Locality
+	2,  // 17: gateway.config.v1.LoadBalancer.policy:type_name -> gateway.config.v1.LoadBalancer.Policy
+	10, // 18: gateway.config.v1.LoadBalancer.hash_policies:type_name -> gateway.config.v1.HashPolicy
+	12, // 19: gateway.config.v1.WeightedClusters.clusters:type_name -> gateway.config.v1.Cluster
+	15, // 20: gateway.config.v1.Cluster.backends:type_name -> gateway.config.v1.Backend
+	33, // 21: gateway.config.v1.Middleware.options:type_name -> google.protobuf.Any
+	16, // 22: gateway.config.v1.Backend.health_check:type_name -> gateway.config.v1.HealthCheck
+	28, // 23: gateway.config.v1.Backend.metadata:type_name -> gateway.config.v1.Backend.MetadataEntry
+	17, // 24: gateway.config.v1.HealthCheck.http:type_name -> gateway.config.v1.HTTPHealthCheck
+	18, // 25: gateway.config.v1.HealthCheck.grpc:type_name -> gateway.config.v1.GRPCHealthCheck
+	32, // 26: gateway.config.v1.HealthCheck.interval:type_name -> google.protobuf.Duration
+	32, // 27: gateway.config.v1.HealthCheck.timeout:type_name -> google.protobuf.Duration
+	32, // 28: gateway.config.v1.OutlierDetection.interval:type_name -> google.protobuf.Duration
+	32, // 29: gateway.config.v1.OutlierDetection.base_ejection_time:type_name -> google.protobuf.Duration
+	32, // 30: gateway.config.v1.OutlierDetection.max_ejection_time:type_name -> google.protobuf.Duration
+	32, // 31: gateway.config.v1.Retry.per_try_timeout:type_name -> google.protobuf.Duration
+	25, // 32: gateway.config.v1.Retry.conditions:type_name -> gateway.config.v1.Condition
+	24, // 33: gateway.config.v1.Retry.hedging:type_name -> gateway.config.v1.Hedging
+	21, // 34: gateway.config.v1.Retry.backoff:type_name -> gateway.config.v1.RetryBackoff
+	22, // 35: gateway.config.v1.Retry.budget:type_name -> gateway.config.v1.RetryBudget
+	23, // 36: gateway.config.v1.Retry.breaker:type_name -> gateway.config.v1.RetryBreaker
+	32, // 37: gateway.config.v1.RetryBackoff.base_interval:type_name -> google.protobuf.Duration
+	32, // 38: gateway.config.v1.RetryBackoff.max_interval:type_name -> google.protobuf.Duration
+	32, // 39: gateway.config.v1.RetryBreaker.window:type_name -> google.protobuf.Duration
+	32, // 40: gateway.config.v1.Hedging.delay:type_name -> google.protobuf.Duration
+	29, // 41: gateway.config.v1.Condition.by_header:type_name -> gateway.config.v1.Condition.header
+	30, // 42: gateway.config.v1.Condition.by_body:type_name -> gateway.config.v1.Condition.body
+	31, // 43: gateway.config.v1.Condition.all_of:type_name -> gateway.config.v1.Condition.conditions
+	25, // 44: gateway.config.v1.Condition.not:type_name -> gateway.config.v1.Condition
+	5,  // 45: gateway.config.v1.Gateway.TlsStoreEntry.value:type_name -> gateway.config.v1.TLS
+	25, // 46: gateway.config.v1.Condition.conditions.conditions:type_name -> gateway.config.v1.Condition
+	47, // [47:47] is the sub-list for method output_type
+	47, // [47:47] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_gateway_config_v1_gateway_proto_init() }
@@ -1042,7 +2845,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TLS); i {
+			switch v := v.(*ServerTLS); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1054,7 +2857,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PriorityConfig); i {
+			switch v := v.(*TLS); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1066,7 +2869,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Endpoint); i {
+			switch v := v.(*PriorityConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1078,7 +2881,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Middleware); i {
+			switch v := v.(*Endpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1090,7 +2893,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Backend); i {
+			switch v := v.(*Locality); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1102,7 +2905,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HealthCheck); i {
+			switch v := v.(*LoadBalancer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1114,7 +2917,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Retry); i {
+			switch v := v.(*HashPolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1126,7 +2929,43 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Condition); i {
+			switch v := v.(*WeightedClusters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cluster); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Streaming); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Middleware); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1138,6 +2977,138 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 		file_gateway_config_v1_gateway_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Backend); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPHealthCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GRPCHealthCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutlierDetection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Retry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryBackoff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryBudget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryBreaker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hedging); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Condition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConditionHeader); i {
 			case 0:
 				return &v.state
@@ -1149,19 +3120,58 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
+		file_gateway_config_v1_gateway_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConditionBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConditionConditions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_config_v1_gateway_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*HashPolicy_Header)(nil),
+		(*HashPolicy_Cookie)(nil),
+		(*HashPolicy_SourceIp)(nil),
+	}
+	file_gateway_config_v1_gateway_proto_msgTypes[12].OneofWrappers = []interface{}{}
+	file_gateway_config_v1_gateway_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*HealthCheck_Http)(nil),
+		(*HealthCheck_Grpc)(nil),
 	}
-	file_gateway_config_v1_gateway_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_gateway_config_v1_gateway_proto_msgTypes[8].OneofWrappers = []interface{}{
+	file_gateway_config_v1_gateway_proto_msgTypes[22].OneofWrappers = []interface{}{
 		(*Condition_ByStatusCode)(nil),
 		(*Condition_ByHeader)(nil),
+		(*Condition_ByGrpcCode)(nil),
+		(*Condition_ByError)(nil),
+		(*Condition_ByMethod)(nil),
+		(*Condition_ByBody)(nil),
+		(*Condition_AllOf)(nil),
+		(*Condition_Not)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gateway_config_v1_gateway_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   13,
+			NumEnums:      3,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
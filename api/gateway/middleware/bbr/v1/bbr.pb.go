@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/bbr/v1/bbr.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BBR_Scope int32
+
+const (
+	// each endpoint has its own limiter
+	BBR_ENDPOINT BBR_Scope = 0
+	// the endpoints with the same name share a limiter
+	BBR_SHARED BBR_Scope = 1
+)
+
+// Enum value maps for BBR_Scope.
+var (
+	BBR_Scope_name = map[int32]string{
+		0: "ENDPOINT",
+		1: "SHARED",
+	}
+	BBR_Scope_value = map[string]int32{
+		"ENDPOINT": 0,
+		"SHARED":   1,
+	}
+)
+
+func (x BBR_Scope) Enum() *BBR_Scope {
+	p := new(BBR_Scope)
+	*p = x
+	return p
+}
+
+func (x BBR_Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BBR_Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_middleware_bbr_v1_bbr_proto_enumTypes[0].Descriptor()
+}
+
+func (BBR_Scope) Type() protoreflect.EnumType {
+	return &file_gateway_middleware_bbr_v1_bbr_proto_enumTypes[0]
+}
+
+func (x BBR_Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BBR_Scope.Descriptor instead.
+func (BBR_Scope) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_middleware_bbr_v1_bbr_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// BBR middleware config.
+type BBR struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the window of the pass and rt statistics, default is 10s
+	Window *durationpb.Duration `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	// the bucket count of the window, default is 100
+	Bucket int32 `protobuf:"varint,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// the cpu usage in per mille to start dropping, default is 800
+	CpuThreshold int64 `protobuf:"varint,3,opt,name=cpu_threshold,json=cpuThreshold,proto3" json:"cpu_threshold,omitempty"`
+	// the cpu quota of the process, eg: 2.5, the usage is measured by the host cpus if not set
+	CpuQuota float64   `protobuf:"fixed64,4,opt,name=cpu_quota,json=cpuQuota,proto3" json:"cpu_quota,omitempty"`
+	Scope    BBR_Scope `protobuf:"varint,5,opt,name=scope,proto3,enum=gateway.middleware.bbr.v1.BBR_Scope" json:"scope,omitempty"`
+	// the name of the shared limiter, required by the shared scope
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *BBR) Reset() {
+	*x = BBR{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_bbr_v1_bbr_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BBR) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BBR) ProtoMessage() {}
+
+func (x *BBR) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_bbr_v1_bbr_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BBR.ProtoReflect.Descriptor instead.
+func (*BBR) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_bbr_v1_bbr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BBR) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *BBR) GetBucket() int32 {
+	if x != nil {
+		return x.Bucket
+	}
+	return 0
+}
+
+func (x *BBR) GetCpuThreshold() int64 {
+	if x != nil {
+		return x.CpuThreshold
+	}
+	return 0
+}
+
+func (x *BBR) GetCpuQuota() float64 {
+	if x != nil {
+		return x.CpuQuota
+	}
+	return 0
+}
+
+func (x *BBR) GetScope() BBR_Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return BBR_ENDPOINT
+}
+
+func (x *BBR) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_gateway_middleware_bbr_v1_bbr_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_bbr_v1_bbr_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x62, 0x62, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x62, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x62, 0x62, 0x72, 0x2e, 0x76, 0x31,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x85, 0x02, 0x0a, 0x03, 0x42, 0x42, 0x52, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x70, 0x75, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x5f,
+	0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x70, 0x75,
+	0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x3a, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x62, 0x62, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x42, 0x52, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x21, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0c,
+	0x0a, 0x08, 0x45, 0x4e, 0x44, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x48, 0x41, 0x52, 0x45, 0x44, 0x10, 0x01, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73,
+	0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f,
+	0x62, 0x62, 0x72, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_bbr_v1_bbr_proto_rawDescOnce sync.Once
+	file_gateway_middleware_bbr_v1_bbr_proto_rawDescData = file_gateway_middleware_bbr_v1_bbr_proto_rawDesc
+)
+
+func file_gateway_middleware_bbr_v1_bbr_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_bbr_v1_bbr_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_bbr_v1_bbr_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_bbr_v1_bbr_proto_rawDescData)
+	})
+	return file_gateway_middleware_bbr_v1_bbr_proto_rawDescData
+}
+
+var file_gateway_middleware_bbr_v1_bbr_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gateway_middleware_bbr_v1_bbr_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_bbr_v1_bbr_proto_goTypes = []interface{}{
+	(BBR_Scope)(0),              // 0: gateway.middleware.bbr.v1.BBR.Scope
+	(*BBR)(nil),                 // 1: gateway.middleware.bbr.v1.BBR
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
+}
+var file_gateway_middleware_bbr_v1_bbr_proto_depIdxs = []int32{
+	2, // 0: gateway.middleware.bbr.v1.BBR.window:type_name -> google.protobuf.Duration
+	0, // 1: gateway.middleware.bbr.v1.BBR.scope:type_name -> gateway.middleware.bbr.v1.BBR.Scope
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_bbr_v1_bbr_proto_init() }
+func file_gateway_middleware_bbr_v1_bbr_proto_init() {
+	if File_gateway_middleware_bbr_v1_bbr_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_bbr_v1_bbr_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BBR); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_bbr_v1_bbr_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_bbr_v1_bbr_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_bbr_v1_bbr_proto_depIdxs,
+		EnumInfos:         file_gateway_middleware_bbr_v1_bbr_proto_enumTypes,
+		MessageInfos:      file_gateway_middleware_bbr_v1_bbr_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_bbr_v1_bbr_proto = out.File
+	file_gateway_middleware_bbr_v1_bbr_proto_rawDesc = nil
+	file_gateway_middleware_bbr_v1_bbr_proto_goTypes = nil
+	file_gateway_middleware_bbr_v1_bbr_proto_depIdxs = nil
+}
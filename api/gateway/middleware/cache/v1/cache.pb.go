@@ -0,0 +1,452 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/cache/v1/cache.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Cache middleware config.
+type Cache struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the endpoints with the same name and storage share the cached responses,
+	// it's also used to purge by /debug/cache/purge, default is default
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Storage:
+	//
+	//	*Cache_Memory
+	//	*Cache_Disk
+	Storage isCache_Storage `protobuf_oneof:"storage"`
+	// the request headers which are part of the cache key, the responses varying
+	// by the other headers are not cached. the responses to the requests with
+	// Authorization or Cookie out of the key are cached only if they're public
+	VaryHeaders []string `protobuf:"bytes,4,rep,name=vary_headers,json=varyHeaders,proto3" json:"vary_headers,omitempty"`
+	// the freshness of the responses without Cache-Control max-age or Expires,
+	// these responses are not cached if not set
+	DefaultTtl *durationpb.Duration `protobuf:"bytes,5,opt,name=default_ttl,json=defaultTtl,proto3" json:"default_ttl,omitempty"`
+	// the max freshness of the cached responses
+	MaxTtl *durationpb.Duration `protobuf:"bytes,6,opt,name=max_ttl,json=maxTtl,proto3" json:"max_ttl,omitempty"`
+	// how long the stale responses are served while revalidating in background,
+	// the stale-while-revalidate of Cache-Control takes precedence
+	StaleWhileRevalidate *durationpb.Duration `protobuf:"bytes,7,opt,name=stale_while_revalidate,json=staleWhileRevalidate,proto3" json:"stale_while_revalidate,omitempty"`
+	// default is GET and HEAD
+	Methods []string `protobuf:"bytes,8,rep,name=methods,proto3" json:"methods,omitempty"`
+	// default is 200, 203, 204, 300, 301, 308, 404, 405, 410, 414 and 501
+	StatusCodes []int32 `protobuf:"varint,9,rep,packed,name=status_codes,json=statusCodes,proto3" json:"status_codes,omitempty"`
+	// the responses larger than it are not cached, default is 1MiB
+	MaxBodySize int64 `protobuf:"varint,10,opt,name=max_body_size,json=maxBodySize,proto3" json:"max_body_size,omitempty"`
+	// do not coalesce the concurrent requests of the same uncached response
+	DisableCoalescing bool `protobuf:"varint,11,opt,name=disable_coalescing,json=disableCoalescing,proto3" json:"disable_coalescing,omitempty"`
+}
+
+func (x *Cache) Reset() {
+	*x = Cache{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_cache_v1_cache_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cache) ProtoMessage() {}
+
+func (x *Cache) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_cache_v1_cache_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cache.ProtoReflect.Descriptor instead.
+func (*Cache) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_cache_v1_cache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cache) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *Cache) GetStorage() isCache_Storage {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (x *Cache) GetMemory() *MemoryStorage {
+	if x, ok := x.GetStorage().(*Cache_Memory); ok {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *Cache) GetDisk() *DiskStorage {
+	if x, ok := x.GetStorage().(*Cache_Disk); ok {
+		return x.Disk
+	}
+	return nil
+}
+
+func (x *Cache) GetVaryHeaders() []string {
+	if x != nil {
+		return x.VaryHeaders
+	}
+	return nil
+}
+
+func (x *Cache) GetDefaultTtl() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultTtl
+	}
+	return nil
+}
+
+func (x *Cache) GetMaxTtl() *durationpb.Duration {
+	if x != nil {
+		return x.MaxTtl
+	}
+	return nil
+}
+
+func (x *Cache) GetStaleWhileRevalidate() *durationpb.Duration {
+	if x != nil {
+		return x.StaleWhileRevalidate
+	}
+	return nil
+}
+
+func (x *Cache) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *Cache) GetStatusCodes() []int32 {
+	if x != nil {
+		return x.StatusCodes
+	}
+	return nil
+}
+
+func (x *Cache) GetMaxBodySize() int64 {
+	if x != nil {
+		return x.MaxBodySize
+	}
+	return 0
+}
+
+func (x *Cache) GetDisableCoalescing() bool {
+	if x != nil {
+		return x.DisableCoalescing
+	}
+	return false
+}
+
+type isCache_Storage interface {
+	isCache_Storage()
+}
+
+type Cache_Memory struct {
+	Memory *MemoryStorage `protobuf:"bytes,2,opt,name=memory,proto3,oneof"`
+}
+
+type Cache_Disk struct {
+	Disk *DiskStorage `protobuf:"bytes,3,opt,name=disk,proto3,oneof"`
+}
+
+func (*Cache_Memory) isCache_Storage() {}
+
+func (*Cache_Disk) isCache_Storage() {}
+
+// MemoryStorage keeps the responses in memory and evicts the least recently used.
+type MemoryStorage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the max bytes of the cached responses, default is 64MiB
+	MaxSize int64 `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+}
+
+func (x *MemoryStorage) Reset() {
+	*x = MemoryStorage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_cache_v1_cache_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryStorage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryStorage) ProtoMessage() {}
+
+func (x *MemoryStorage) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_cache_v1_cache_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryStorage.ProtoReflect.Descriptor instead.
+func (*MemoryStorage) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_cache_v1_cache_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MemoryStorage) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+// DiskStorage keeps the responses in files and evicts the least recently used.
+type DiskStorage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dir string `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	// the max bytes of the cached responses, default is 1GiB
+	MaxSize int64 `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+}
+
+func (x *DiskStorage) Reset() {
+	*x = DiskStorage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_cache_v1_cache_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiskStorage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskStorage) ProtoMessage() {}
+
+func (x *DiskStorage) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_cache_v1_cache_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskStorage.ProtoReflect.Descriptor instead.
+func (*DiskStorage) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_cache_v1_cache_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DiskStorage) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *DiskStorage) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+var File_gateway_middleware_cache_v1_cache_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_cache_v1_cache_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x04, 0x0a, 0x05, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x44, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x61, 0x63, 0x68, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x3e, 0x0a, 0x04, 0x64, 0x69,
+	0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x64, 0x69, 0x73, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61,
+	0x72, 0x79, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x76, 0x61, 0x72, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a,
+	0x0b, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x74, 0x6c, 0x12, 0x32, 0x0a, 0x07, 0x6d, 0x61, 0x78,
+	0x5f, 0x74, 0x74, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x54, 0x74, 0x6c, 0x12, 0x4f, 0x0a,
+	0x16, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x5f, 0x77, 0x68, 0x69, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x14, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x57,
+	0x68, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d,
+	0x61, 0x78, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x2d, 0x0a, 0x12, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x61, 0x6c, 0x65,
+	0x73, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x64, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x61, 0x6c, 0x65, 0x73, 0x63, 0x69, 0x6e, 0x67, 0x42, 0x09,
+	0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x2a, 0x0a, 0x0d, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61,
+	0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61,
+	0x78, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x3a, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69,
+	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x2f, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_cache_v1_cache_proto_rawDescOnce sync.Once
+	file_gateway_middleware_cache_v1_cache_proto_rawDescData = file_gateway_middleware_cache_v1_cache_proto_rawDesc
+)
+
+func file_gateway_middleware_cache_v1_cache_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_cache_v1_cache_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_cache_v1_cache_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_cache_v1_cache_proto_rawDescData)
+	})
+	return file_gateway_middleware_cache_v1_cache_proto_rawDescData
+}
+
+var file_gateway_middleware_cache_v1_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_gateway_middleware_cache_v1_cache_proto_goTypes = []interface{}{
+	(*Cache)(nil),               // 0: gateway.middleware.cache.v1.Cache
+	(*MemoryStorage)(nil),       // 1: gateway.middleware.cache.v1.MemoryStorage
+	(*DiskStorage)(nil),         // 2: gateway.middleware.cache.v1.DiskStorage
+	(*durationpb.Duration)(nil), // 3: google.protobuf.Duration
+}
+var file_gateway_middleware_cache_v1_cache_proto_depIdxs = []int32{
+	1, // 0: gateway.middleware.cache.v1.Cache.memory:type_name -> gateway.middleware.cache.v1.MemoryStorage
+	2, // 1: gateway.middleware.cache.v1.Cache.disk:type_name -> gateway.middleware.cache.v1.DiskStorage
+	3, // 2: gateway.middleware.cache.v1.Cache.default_ttl:type_name -> google.protobuf.Duration
+	3, // 3: gateway.middleware.cache.v1.Cache.max_ttl:type_name -> google.protobuf.Duration
+	3, // 4: gateway.middleware.cache.v1.Cache.stale_while_revalidate:type_name -> google.protobuf.Duration
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_cache_v1_cache_proto_init() }
+func file_gateway_middleware_cache_v1_cache_proto_init() {
+	if File_gateway_middleware_cache_v1_cache_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_cache_v1_cache_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cache); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_cache_v1_cache_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemoryStorage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_cache_v1_cache_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiskStorage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_cache_v1_cache_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Cache_Memory)(nil),
+		(*Cache_Disk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_cache_v1_cache_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_cache_v1_cache_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_cache_v1_cache_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_cache_v1_cache_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_cache_v1_cache_proto = out.File
+	file_gateway_middleware_cache_v1_cache_proto_rawDesc = nil
+	file_gateway_middleware_cache_v1_cache_proto_goTypes = nil
+	file_gateway_middleware_cache_v1_cache_proto_depIdxs = nil
+}
@@ -22,6 +22,54 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Scope int32
+
+const (
+	// one breaker of the endpoint
+	Scope_ENDPOINT Scope = 0
+	// one breaker per node, the nodes with the open breakers are skipped by the selector
+	Scope_NODE Scope = 1
+)
+
+// Enum value maps for Scope.
+var (
+	Scope_name = map[int32]string{
+		0: "ENDPOINT",
+		1: "NODE",
+	}
+	Scope_value = map[string]int32{
+		"ENDPOINT": 0,
+		"NODE":     1,
+	}
+)
+
+func (x Scope) Enum() *Scope {
+	p := new(Scope)
+	*p = x
+	return p
+}
+
+func (x Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_enumTypes[0].Descriptor()
+}
+
+func (Scope) Type() protoreflect.EnumType {
+	return &file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_enumTypes[0]
+}
+
+func (x Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Scope.Descriptor instead.
+func (Scope) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDescGZIP(), []int{0}
+}
+
 // CircuitBreaker middleware config.
 type CircuitBreaker struct {
 	state         protoimpl.MessageState
@@ -32,6 +80,7 @@ type CircuitBreaker struct {
 	//
 	//	*CircuitBreaker_SuccessRatio
 	//	*CircuitBreaker_Ratio
+	//	*CircuitBreaker_ConsecutiveFailures
 	Trigger isCircuitBreaker_Trigger `protobuf_oneof:"trigger"`
 	// Types that are assignable to Action:
 	//
@@ -39,6 +88,8 @@ type CircuitBreaker struct {
 	//	*CircuitBreaker_BackupService
 	Action          isCircuitBreaker_Action `protobuf_oneof:"action"`
 	AssertCondtions []*v1.Condition         `protobuf:"bytes,5,rep,name=assert_condtions,json=assertCondtions,proto3" json:"assert_condtions,omitempty"`
+	// the scope of the breakers, only the consecutive failures trigger breaks the nodes.
+	Scope Scope `protobuf:"varint,7,opt,name=scope,proto3,enum=gateway.middleware.circuitbreaker.v1.Scope" json:"scope,omitempty"`
 }
 
 func (x *CircuitBreaker) Reset() {
@@ -94,6 +145,13 @@ func (x *CircuitBreaker) GetRatio() int64 {
 	return 0
 }
 
+func (x *CircuitBreaker) GetConsecutiveFailures() *ConsecutiveFailures {
+	if x, ok := x.GetTrigger().(*CircuitBreaker_ConsecutiveFailures); ok {
+		return x.ConsecutiveFailures
+	}
+	return nil
+}
+
 func (m *CircuitBreaker) GetAction() isCircuitBreaker_Action {
 	if m != nil {
 		return m.Action
@@ -122,6 +180,13 @@ func (x *CircuitBreaker) GetAssertCondtions() []*v1.Condition {
 	return nil
 }
 
+func (x *CircuitBreaker) GetScope() Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return Scope_ENDPOINT
+}
+
 type isCircuitBreaker_Trigger interface {
 	isCircuitBreaker_Trigger()
 }
@@ -134,10 +199,16 @@ type CircuitBreaker_Ratio struct {
 	Ratio int64 `protobuf:"varint,2,opt,name=ratio,proto3,oneof"`
 }
 
+type CircuitBreaker_ConsecutiveFailures struct {
+	ConsecutiveFailures *ConsecutiveFailures `protobuf:"bytes,6,opt,name=consecutive_failures,json=consecutiveFailures,proto3,oneof"`
+}
+
 func (*CircuitBreaker_SuccessRatio) isCircuitBreaker_Trigger() {}
 
 func (*CircuitBreaker_Ratio) isCircuitBreaker_Trigger() {}
 
+func (*CircuitBreaker_ConsecutiveFailures) isCircuitBreaker_Trigger() {}
+
 type isCircuitBreaker_Action interface {
 	isCircuitBreaker_Action()
 }
@@ -390,6 +461,74 @@ func (x *SuccessRatio) GetWindow() *durationpb.Duration {
 	return nil
 }
 
+// ConsecutiveFailures opens the breaker after the consecutive failures,
+// the breaker turns half-open after the open duration and closes once all the probes succeeded.
+type ConsecutiveFailures struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default 5
+	Failures int32 `protobuf:"varint,1,opt,name=failures,proto3" json:"failures,omitempty"`
+	// default 30s
+	OpenDuration *durationpb.Duration `protobuf:"bytes,2,opt,name=open_duration,json=openDuration,proto3" json:"open_duration,omitempty"`
+	// the number of the probes in half-open state, default 1
+	HalfOpenRequests int32 `protobuf:"varint,3,opt,name=half_open_requests,json=halfOpenRequests,proto3" json:"half_open_requests,omitempty"`
+}
+
+func (x *ConsecutiveFailures) Reset() {
+	*x = ConsecutiveFailures{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsecutiveFailures) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsecutiveFailures) ProtoMessage() {}
+
+func (x *ConsecutiveFailures) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsecutiveFailures.ProtoReflect.Descriptor instead.
+func (*ConsecutiveFailures) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConsecutiveFailures) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *ConsecutiveFailures) GetOpenDuration() *durationpb.Duration {
+	if x != nil {
+		return x.OpenDuration
+	}
+	return nil
+}
+
+func (x *ConsecutiveFailures) GetHalfOpenRequests() int32 {
+	if x != nil {
+		return x.HalfOpenRequests
+	}
+	return 0
+}
+
 var File_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto protoreflect.FileDescriptor
 
 var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDesc = []byte{
@@ -403,7 +542,7 @@ var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDesc = []b
 	0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x1f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x9a, 0x03, 0x0a, 0x0e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72,
+	0x74, 0x6f, 0x22, 0xcd, 0x04, 0x0a, 0x0e, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72,
 	0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x59, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
 	0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67,
 	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
@@ -411,7 +550,14 @@ var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDesc = []b
 	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x69, 0x6f,
 	0x48, 0x00, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x69, 0x6f,
 	0x12, 0x16, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48,
-	0x00, 0x52, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x59, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70,
+	0x00, 0x52, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x6e, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x73,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x48, 0x00, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x59, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x32, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
 	0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x62, 0x72, 0x65, 0x61,
@@ -427,39 +573,55 @@ var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDesc = []b
 	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61,
 	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
 	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x61, 0x73, 0x73, 0x65, 0x72,
-	0x74, 0x43, 0x6f, 0x6e, 0x64, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x74, 0x72,
-	0x69, 0x67, 0x67, 0x65, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22,
-	0x30, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x89, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
-	0x6f, 0x64, 0x65, 0x12, 0x44, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69,
-	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74,
-	0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x48, 0x0a,
-	0x0d, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37,
-	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x65,
-	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x75,
-	0x63, 0x6b, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f,
-	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x63,
-	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x76, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x43, 0x6f, 0x6e, 0x64, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x41, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x30, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x44, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x63, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79,
+	0x22, 0x48, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x37, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x0c, 0x53,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x9f, 0x01, 0x0a, 0x13, 0x43,
+	0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3e,
+	0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x6f, 0x70, 0x65, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c,
+	0x0a, 0x12, 0x68, 0x61, 0x6c, 0x66, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x68, 0x61, 0x6c, 0x66,
+	0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x2a, 0x1f, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x4e, 0x44, 0x50, 0x4f, 0x49, 0x4e,
+	0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x44, 0x45, 0x10, 0x01, 0x42, 0x47, 0x5a,
+	0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b,
+	0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -474,30 +636,36 @@ func file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDescGZIP(
 	return file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDescData
 }
 
-var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_goTypes = []interface{}{
-	(*CircuitBreaker)(nil),      // 0: gateway.middleware.circuitbreaker.v1.CircuitBreaker
-	(*Header)(nil),              // 1: gateway.middleware.circuitbreaker.v1.Header
-	(*ResponseData)(nil),        // 2: gateway.middleware.circuitbreaker.v1.ResponseData
-	(*BackupService)(nil),       // 3: gateway.middleware.circuitbreaker.v1.BackupService
-	(*SuccessRatio)(nil),        // 4: gateway.middleware.circuitbreaker.v1.SuccessRatio
-	(*v1.Condition)(nil),        // 5: gateway.config.v1.Condition
-	(*v1.Endpoint)(nil),         // 6: gateway.config.v1.Endpoint
-	(*durationpb.Duration)(nil), // 7: google.protobuf.Duration
+	(Scope)(0),                  // 0: gateway.middleware.circuitbreaker.v1.Scope
+	(*CircuitBreaker)(nil),      // 1: gateway.middleware.circuitbreaker.v1.CircuitBreaker
+	(*Header)(nil),              // 2: gateway.middleware.circuitbreaker.v1.Header
+	(*ResponseData)(nil),        // 3: gateway.middleware.circuitbreaker.v1.ResponseData
+	(*BackupService)(nil),       // 4: gateway.middleware.circuitbreaker.v1.BackupService
+	(*SuccessRatio)(nil),        // 5: gateway.middleware.circuitbreaker.v1.SuccessRatio
+	(*ConsecutiveFailures)(nil), // 6: gateway.middleware.circuitbreaker.v1.ConsecutiveFailures
+	(*v1.Condition)(nil),        // 7: gateway.config.v1.Condition
+	(*v1.Endpoint)(nil),         // 8: gateway.config.v1.Endpoint
+	(*durationpb.Duration)(nil), // 9: google.protobuf.Duration
 }
 var file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_depIdxs = []int32{
-	4, // 0: gateway.middleware.circuitbreaker.v1.CircuitBreaker.success_ratio:type_name -> gateway.middleware.circuitbreaker.v1.SuccessRatio
-	2, // 1: gateway.middleware.circuitbreaker.v1.CircuitBreaker.response_data:type_name -> gateway.middleware.circuitbreaker.v1.ResponseData
-	3, // 2: gateway.middleware.circuitbreaker.v1.CircuitBreaker.backup_service:type_name -> gateway.middleware.circuitbreaker.v1.BackupService
-	5, // 3: gateway.middleware.circuitbreaker.v1.CircuitBreaker.assert_condtions:type_name -> gateway.config.v1.Condition
-	1, // 4: gateway.middleware.circuitbreaker.v1.ResponseData.header:type_name -> gateway.middleware.circuitbreaker.v1.Header
-	6, // 5: gateway.middleware.circuitbreaker.v1.BackupService.endpoint:type_name -> gateway.config.v1.Endpoint
-	7, // 6: gateway.middleware.circuitbreaker.v1.SuccessRatio.window:type_name -> google.protobuf.Duration
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	5,  // 0: gateway.middleware.circuitbreaker.v1.CircuitBreaker.success_ratio:type_name -> gateway.middleware.circuitbreaker.v1.SuccessRatio
+	6,  // 1: gateway.middleware.circuitbreaker.v1.CircuitBreaker.consecutive_failures:type_name -> gateway.middleware.circuitbreaker.v1.ConsecutiveFailures
+	3,  // 2: gateway.middleware.circuitbreaker.v1.CircuitBreaker.response_data:type_name -> gateway.middleware.circuitbreaker.v1.ResponseData
+	4,  // 3: gateway.middleware.circuitbreaker.v1.CircuitBreaker.backup_service:type_name -> gateway.middleware.circuitbreaker.v1.BackupService
+	7,  // 4: gateway.middleware.circuitbreaker.v1.CircuitBreaker.assert_condtions:type_name -> gateway.config.v1.Condition
+	0,  // 5: gateway.middleware.circuitbreaker.v1.CircuitBreaker.scope:type_name -> gateway.middleware.circuitbreaker.v1.Scope
+	2,  // 6: gateway.middleware.circuitbreaker.v1.ResponseData.header:type_name -> gateway.middleware.circuitbreaker.v1.Header
+	8,  // 7: gateway.middleware.circuitbreaker.v1.BackupService.endpoint:type_name -> gateway.config.v1.Endpoint
+	9,  // 8: gateway.middleware.circuitbreaker.v1.SuccessRatio.window:type_name -> google.protobuf.Duration
+	9,  // 9: gateway.middleware.circuitbreaker.v1.ConsecutiveFailures.open_duration:type_name -> google.protobuf.Duration
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_init() }
@@ -566,10 +734,23 @@ func file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_init() {
 				return nil
 			}
 		}
+		file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsecutiveFailures); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*CircuitBreaker_SuccessRatio)(nil),
 		(*CircuitBreaker_Ratio)(nil),
+		(*CircuitBreaker_ConsecutiveFailures)(nil),
 		(*CircuitBreaker_ResponseData)(nil),
 		(*CircuitBreaker_BackupService)(nil),
 	}
@@ -578,13 +759,14 @@ func file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      1,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_goTypes,
 		DependencyIndexes: file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_depIdxs,
+		EnumInfos:         file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_enumTypes,
 		MessageInfos:      file_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto_msgTypes,
 	}.Build()
 	File_gateway_middleware_circuitbreaker_v1_circuitbreaker_proto = out.File
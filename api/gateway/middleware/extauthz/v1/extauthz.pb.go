@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/extauthz/v1/extauthz.proto
+
+package v1
+
+import (
+	v1 "github.com/go-kratos/gateway/api/gateway/config/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExtAuthz middleware config.
+type ExtAuthz struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the external authorization service, the protocol decides how it's called:
+	// HTTP: the request method and path are forwarded with path_prefix, 2xx responses allow the request.
+	// GRPC: envoy.service.auth.v3.Authorization/Check is called.
+	Service *v1.Endpoint `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// the timeout of the authorization call, default is 1s
+	Timeout *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// the request headers sent to the authorization service, default is Authorization
+	AllowedHeaders []string `protobuf:"bytes,3,rep,name=allowed_headers,json=allowedHeaders,proto3" json:"allowed_headers,omitempty"`
+	// the headers of the HTTP authorization response which are injected into the upstream request
+	AllowedUpstreamHeaders []string `protobuf:"bytes,4,rep,name=allowed_upstream_headers,json=allowedUpstreamHeaders,proto3" json:"allowed_upstream_headers,omitempty"`
+	// the headers of the denied authorization response which are returned to the client, empty means all
+	AllowedClientHeaders []string `protobuf:"bytes,5,rep,name=allowed_client_headers,json=allowedClientHeaders,proto3" json:"allowed_client_headers,omitempty"`
+	// allow the request when the authorization service is unavailable
+	FailureModeAllow bool `protobuf:"varint,6,opt,name=failure_mode_allow,json=failureModeAllow,proto3" json:"failure_mode_allow,omitempty"`
+	// the path prefix of the HTTP authorization request
+	PathPrefix string `protobuf:"bytes,7,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	// the status code replied when the authorization service is unavailable, default is 403
+	StatusOnError int32 `protobuf:"varint,8,opt,name=status_on_error,json=statusOnError,proto3" json:"status_on_error,omitempty"`
+}
+
+func (x *ExtAuthz) Reset() {
+	*x = ExtAuthz{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_extauthz_v1_extauthz_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtAuthz) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtAuthz) ProtoMessage() {}
+
+func (x *ExtAuthz) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_extauthz_v1_extauthz_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtAuthz.ProtoReflect.Descriptor instead.
+func (*ExtAuthz) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExtAuthz) GetService() *v1.Endpoint {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ExtAuthz) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ExtAuthz) GetAllowedHeaders() []string {
+	if x != nil {
+		return x.AllowedHeaders
+	}
+	return nil
+}
+
+func (x *ExtAuthz) GetAllowedUpstreamHeaders() []string {
+	if x != nil {
+		return x.AllowedUpstreamHeaders
+	}
+	return nil
+}
+
+func (x *ExtAuthz) GetAllowedClientHeaders() []string {
+	if x != nil {
+		return x.AllowedClientHeaders
+	}
+	return nil
+}
+
+func (x *ExtAuthz) GetFailureModeAllow() bool {
+	if x != nil {
+		return x.FailureModeAllow
+	}
+	return false
+}
+
+func (x *ExtAuthz) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+func (x *ExtAuthz) GetStatusOnError() int32 {
+	if x != nil {
+		return x.StatusOnError
+	}
+	return 0
+}
+
+var File_gateway_middleware_extauthz_v1_extauthz_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_extauthz_v1_extauthz_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x65, 0x78, 0x74, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2f, 0x76, 0x31,
+	0x2f, 0x65, 0x78, 0x74, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x65, 0x78, 0x74, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x76, 0x31, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x86, 0x03, 0x0a, 0x08, 0x45, 0x78, 0x74, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x12, 0x35, 0x0a,
+	0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x75, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x55, 0x70, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x16,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x6f,
+	0x64, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f,
+	0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65,
+	0x2f, 0x65, 0x78, 0x74, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescOnce sync.Once
+	file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescData = file_gateway_middleware_extauthz_v1_extauthz_proto_rawDesc
+)
+
+func file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescData)
+	})
+	return file_gateway_middleware_extauthz_v1_extauthz_proto_rawDescData
+}
+
+var file_gateway_middleware_extauthz_v1_extauthz_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_extauthz_v1_extauthz_proto_goTypes = []interface{}{
+	(*ExtAuthz)(nil),            // 0: gateway.middleware.extauthz.v1.ExtAuthz
+	(*v1.Endpoint)(nil),         // 1: gateway.config.v1.Endpoint
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
+}
+var file_gateway_middleware_extauthz_v1_extauthz_proto_depIdxs = []int32{
+	1, // 0: gateway.middleware.extauthz.v1.ExtAuthz.service:type_name -> gateway.config.v1.Endpoint
+	2, // 1: gateway.middleware.extauthz.v1.ExtAuthz.timeout:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_extauthz_v1_extauthz_proto_init() }
+func file_gateway_middleware_extauthz_v1_extauthz_proto_init() {
+	if File_gateway_middleware_extauthz_v1_extauthz_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_extauthz_v1_extauthz_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtAuthz); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_extauthz_v1_extauthz_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_extauthz_v1_extauthz_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_extauthz_v1_extauthz_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_extauthz_v1_extauthz_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_extauthz_v1_extauthz_proto = out.File
+	file_gateway_middleware_extauthz_v1_extauthz_proto_rawDesc = nil
+	file_gateway_middleware_extauthz_v1_extauthz_proto_goTypes = nil
+	file_gateway_middleware_extauthz_v1_extauthz_proto_depIdxs = nil
+}
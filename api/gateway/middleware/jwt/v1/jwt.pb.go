@@ -0,0 +1,484 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/jwt/v1/jwt.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Jwt middleware config.
+type Jwt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the expected iss claim, empty means not checked
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// the token must contain at least one of the audiences in the aud claim, empty means not checked
+	Audiences []string `protobuf:"bytes,2,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	// the allowed signing algorithms, eg: RS256, ES256, HS256, default is RS256 and ES256
+	Algorithms []string `protobuf:"bytes,3,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+	// Types that are assignable to JwksSource:
+	//
+	//	*Jwt_JwksUri
+	//	*Jwt_JwksFile
+	//	*Jwt_Jwks
+	JwksSource isJwt_JwksSource `protobuf_oneof:"jwks_source"`
+	// the interval to refresh the JWKS from jwks_uri or jwks_file, default is 5m
+	JwksRefreshInterval *durationpb.Duration `protobuf:"bytes,7,opt,name=jwks_refresh_interval,json=jwksRefreshInterval,proto3" json:"jwks_refresh_interval,omitempty"`
+	// the header carrying the bearer token, default is Authorization
+	TokenHeader string `protobuf:"bytes,8,opt,name=token_header,json=tokenHeader,proto3" json:"token_header,omitempty"`
+	// the query parameter carrying the token if the header is absent
+	TokenQuery      string           `protobuf:"bytes,9,opt,name=token_query,json=tokenQuery,proto3" json:"token_query,omitempty"`
+	RequiredClaims  []*RequiredClaim `protobuf:"bytes,10,rep,name=required_claims,json=requiredClaims,proto3" json:"required_claims,omitempty"`
+	ClaimsToHeaders []*ClaimToHeader `protobuf:"bytes,11,rep,name=claims_to_headers,json=claimsToHeaders,proto3" json:"claims_to_headers,omitempty"`
+	// remove the token from the upstream request
+	StripToken bool `protobuf:"varint,12,opt,name=strip_token,json=stripToken,proto3" json:"strip_token,omitempty"`
+	// the allowed clock skew on exp and nbf, default is 60s
+	ClockSkew *durationpb.Duration `protobuf:"bytes,13,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`
+}
+
+func (x *Jwt) Reset() {
+	*x = Jwt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Jwt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Jwt) ProtoMessage() {}
+
+func (x *Jwt) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Jwt.ProtoReflect.Descriptor instead.
+func (*Jwt) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_jwt_v1_jwt_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Jwt) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Jwt) GetAudiences() []string {
+	if x != nil {
+		return x.Audiences
+	}
+	return nil
+}
+
+func (x *Jwt) GetAlgorithms() []string {
+	if x != nil {
+		return x.Algorithms
+	}
+	return nil
+}
+
+func (m *Jwt) GetJwksSource() isJwt_JwksSource {
+	if m != nil {
+		return m.JwksSource
+	}
+	return nil
+}
+
+func (x *Jwt) GetJwksUri() string {
+	if x, ok := x.GetJwksSource().(*Jwt_JwksUri); ok {
+		return x.JwksUri
+	}
+	return ""
+}
+
+func (x *Jwt) GetJwksFile() string {
+	if x, ok := x.GetJwksSource().(*Jwt_JwksFile); ok {
+		return x.JwksFile
+	}
+	return ""
+}
+
+func (x *Jwt) GetJwks() string {
+	if x, ok := x.GetJwksSource().(*Jwt_Jwks); ok {
+		return x.Jwks
+	}
+	return ""
+}
+
+func (x *Jwt) GetJwksRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.JwksRefreshInterval
+	}
+	return nil
+}
+
+func (x *Jwt) GetTokenHeader() string {
+	if x != nil {
+		return x.TokenHeader
+	}
+	return ""
+}
+
+func (x *Jwt) GetTokenQuery() string {
+	if x != nil {
+		return x.TokenQuery
+	}
+	return ""
+}
+
+func (x *Jwt) GetRequiredClaims() []*RequiredClaim {
+	if x != nil {
+		return x.RequiredClaims
+	}
+	return nil
+}
+
+func (x *Jwt) GetClaimsToHeaders() []*ClaimToHeader {
+	if x != nil {
+		return x.ClaimsToHeaders
+	}
+	return nil
+}
+
+func (x *Jwt) GetStripToken() bool {
+	if x != nil {
+		return x.StripToken
+	}
+	return false
+}
+
+func (x *Jwt) GetClockSkew() *durationpb.Duration {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return nil
+}
+
+type isJwt_JwksSource interface {
+	isJwt_JwksSource()
+}
+
+type Jwt_JwksUri struct {
+	// fetch the JWKS from the URL, eg: https://example.com/.well-known/jwks.json
+	JwksUri string `protobuf:"bytes,4,opt,name=jwks_uri,json=jwksUri,proto3,oneof"`
+}
+
+type Jwt_JwksFile struct {
+	// load the JWKS from the local file
+	JwksFile string `protobuf:"bytes,5,opt,name=jwks_file,json=jwksFile,proto3,oneof"`
+}
+
+type Jwt_Jwks struct {
+	// the inline JWKS
+	Jwks string `protobuf:"bytes,6,opt,name=jwks,proto3,oneof"`
+}
+
+func (*Jwt_JwksUri) isJwt_JwksSource() {}
+
+func (*Jwt_JwksFile) isJwt_JwksSource() {}
+
+func (*Jwt_Jwks) isJwt_JwksSource() {}
+
+// RequiredClaim requires the claim to be present, and to match one of the values if any.
+type RequiredClaim struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the claim name, nested claims are separated by dots, eg: realm_access.roles
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the string value or any element of the array value must be one of the values
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *RequiredClaim) Reset() {
+	*x = RequiredClaim{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequiredClaim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequiredClaim) ProtoMessage() {}
+
+func (x *RequiredClaim) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequiredClaim.ProtoReflect.Descriptor instead.
+func (*RequiredClaim) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_jwt_v1_jwt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequiredClaim) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RequiredClaim) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// ClaimToHeader forwards the claim to the upstream in the header.
+type ClaimToHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the claim name, nested claims are separated by dots
+	Claim  string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	Header string `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+}
+
+func (x *ClaimToHeader) Reset() {
+	*x = ClaimToHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimToHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimToHeader) ProtoMessage() {}
+
+func (x *ClaimToHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimToHeader.ProtoReflect.Descriptor instead.
+func (*ClaimToHeader) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_jwt_v1_jwt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClaimToHeader) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *ClaimToHeader) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+var File_gateway_middleware_jwt_v1_jwt_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_jwt_v1_jwt_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x6a, 0x77, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6a, 0x77, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x6a, 0x77, 0x74, 0x2e, 0x76, 0x31,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xd3, 0x04, 0x0a, 0x03, 0x4a, 0x77, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x12, 0x1b,
+	0x0a, 0x08, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x07, 0x6a, 0x77, 0x6b, 0x73, 0x55, 0x72, 0x69, 0x12, 0x1d, 0x0a, 0x09, 0x6a,
+	0x77, 0x6b, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x08, 0x6a, 0x77, 0x6b, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x04, 0x6a, 0x77,
+	0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x77, 0x6b, 0x73,
+	0x12, 0x4d, 0x0a, 0x15, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x6a, 0x77, 0x6b, 0x73,
+	0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x51, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f,
+	0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x6a, 0x77, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x12, 0x54, 0x0a, 0x11, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73,
+	0x5f, 0x74, 0x6f, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64,
+	0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x6a, 0x77, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x54, 0x6f, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x73, 0x54, 0x6f, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x69, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x38, 0x0a,
+	0x0a, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6c,
+	0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x42, 0x0d, 0x0a, 0x0b, 0x6a, 0x77, 0x6b, 0x73, 0x5f,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x3b, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x0d, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x6f, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x6a, 0x77, 0x74, 0x2f, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_jwt_v1_jwt_proto_rawDescOnce sync.Once
+	file_gateway_middleware_jwt_v1_jwt_proto_rawDescData = file_gateway_middleware_jwt_v1_jwt_proto_rawDesc
+)
+
+func file_gateway_middleware_jwt_v1_jwt_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_jwt_v1_jwt_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_jwt_v1_jwt_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_jwt_v1_jwt_proto_rawDescData)
+	})
+	return file_gateway_middleware_jwt_v1_jwt_proto_rawDescData
+}
+
+var file_gateway_middleware_jwt_v1_jwt_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_gateway_middleware_jwt_v1_jwt_proto_goTypes = []interface{}{
+	(*Jwt)(nil),                 // 0: gateway.middleware.jwt.v1.Jwt
+	(*RequiredClaim)(nil),       // 1: gateway.middleware.jwt.v1.RequiredClaim
+	(*ClaimToHeader)(nil),       // 2: gateway.middleware.jwt.v1.ClaimToHeader
+	(*durationpb.Duration)(nil), // 3: google.protobuf.Duration
+}
+var file_gateway_middleware_jwt_v1_jwt_proto_depIdxs = []int32{
+	3, // 0: gateway.middleware.jwt.v1.Jwt.jwks_refresh_interval:type_name -> google.protobuf.Duration
+	1, // 1: gateway.middleware.jwt.v1.Jwt.required_claims:type_name -> gateway.middleware.jwt.v1.RequiredClaim
+	2, // 2: gateway.middleware.jwt.v1.Jwt.claims_to_headers:type_name -> gateway.middleware.jwt.v1.ClaimToHeader
+	3, // 3: gateway.middleware.jwt.v1.Jwt.clock_skew:type_name -> google.protobuf.Duration
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_jwt_v1_jwt_proto_init() }
+func file_gateway_middleware_jwt_v1_jwt_proto_init() {
+	if File_gateway_middleware_jwt_v1_jwt_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Jwt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequiredClaim); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimToHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_jwt_v1_jwt_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Jwt_JwksUri)(nil),
+		(*Jwt_JwksFile)(nil),
+		(*Jwt_Jwks)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_jwt_v1_jwt_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_jwt_v1_jwt_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_jwt_v1_jwt_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_jwt_v1_jwt_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_jwt_v1_jwt_proto = out.File
+	file_gateway_middleware_jwt_v1_jwt_proto_rawDesc = nil
+	file_gateway_middleware_jwt_v1_jwt_proto_goTypes = nil
+	file_gateway_middleware_jwt_v1_jwt_proto_depIdxs = nil
+}
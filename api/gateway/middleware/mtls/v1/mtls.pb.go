@@ -0,0 +1,196 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/mtls/v1/mtls.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Mtls middleware config.
+type Mtls struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// reject requests without a verified client certificate
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// the header forwarding the subject of the client certificate, eg: x-client-subject
+	SubjectHeader string `protobuf:"bytes,2,opt,name=subject_header,json=subjectHeader,proto3" json:"subject_header,omitempty"`
+	// the header forwarding the comma separated SANs of the client certificate, eg: x-client-san
+	SanHeader string `protobuf:"bytes,3,opt,name=san_header,json=sanHeader,proto3" json:"san_header,omitempty"`
+	// the header forwarding the SPIFFE ID of the client certificate, eg: x-client-spiffe-id
+	SpiffeIdHeader string `protobuf:"bytes,4,opt,name=spiffe_id_header,json=spiffeIdHeader,proto3" json:"spiffe_id_header,omitempty"`
+	// the identities allowed to access the endpoint, matched against the subject common name,
+	// SANs and SPIFFE ID, a trailing * matches any suffix, eg: spiffe://example.org/ns/prod/*
+	AllowedIdentities []string `protobuf:"bytes,5,rep,name=allowed_identities,json=allowedIdentities,proto3" json:"allowed_identities,omitempty"`
+}
+
+func (x *Mtls) Reset() {
+	*x = Mtls{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_mtls_v1_mtls_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mtls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mtls) ProtoMessage() {}
+
+func (x *Mtls) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_mtls_v1_mtls_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mtls.ProtoReflect.Descriptor instead.
+func (*Mtls) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_mtls_v1_mtls_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Mtls) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Mtls) GetSubjectHeader() string {
+	if x != nil {
+		return x.SubjectHeader
+	}
+	return ""
+}
+
+func (x *Mtls) GetSanHeader() string {
+	if x != nil {
+		return x.SanHeader
+	}
+	return ""
+}
+
+func (x *Mtls) GetSpiffeIdHeader() string {
+	if x != nil {
+		return x.SpiffeIdHeader
+	}
+	return ""
+}
+
+func (x *Mtls) GetAllowedIdentities() []string {
+	if x != nil {
+		return x.AllowedIdentities
+	}
+	return nil
+}
+
+var File_gateway_middleware_mtls_v1_mtls_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_mtls_v1_mtls_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x6d, 0x74, 0x6c, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x74, 0x6c,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x6d, 0x74, 0x6c, 0x73,
+	0x2e, 0x76, 0x31, 0x22, 0xc1, 0x01, 0x0a, 0x04, 0x4d, 0x74, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x28,
+	0x0a, 0x10, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65,
+	0x49, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x6d,
+	0x74, 0x6c, 0x73, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_mtls_v1_mtls_proto_rawDescOnce sync.Once
+	file_gateway_middleware_mtls_v1_mtls_proto_rawDescData = file_gateway_middleware_mtls_v1_mtls_proto_rawDesc
+)
+
+func file_gateway_middleware_mtls_v1_mtls_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_mtls_v1_mtls_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_mtls_v1_mtls_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_mtls_v1_mtls_proto_rawDescData)
+	})
+	return file_gateway_middleware_mtls_v1_mtls_proto_rawDescData
+}
+
+var file_gateway_middleware_mtls_v1_mtls_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_mtls_v1_mtls_proto_goTypes = []interface{}{
+	(*Mtls)(nil), // 0: gateway.middleware.mtls.v1.Mtls
+}
+var file_gateway_middleware_mtls_v1_mtls_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_mtls_v1_mtls_proto_init() }
+func file_gateway_middleware_mtls_v1_mtls_proto_init() {
+	if File_gateway_middleware_mtls_v1_mtls_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_mtls_v1_mtls_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mtls); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_mtls_v1_mtls_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_mtls_v1_mtls_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_mtls_v1_mtls_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_mtls_v1_mtls_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_mtls_v1_mtls_proto = out.File
+	file_gateway_middleware_mtls_v1_mtls_proto_rawDesc = nil
+	file_gateway_middleware_mtls_v1_mtls_proto_goTypes = nil
+	file_gateway_middleware_mtls_v1_mtls_proto_depIdxs = nil
+}
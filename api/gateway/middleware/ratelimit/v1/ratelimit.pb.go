@@ -0,0 +1,923 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/ratelimit/v1/ratelimit.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Limit_Algorithm int32
+
+const (
+	Limit_TOKEN_BUCKET   Limit_Algorithm = 0
+	Limit_SLIDING_WINDOW Limit_Algorithm = 1
+)
+
+// Enum value maps for Limit_Algorithm.
+var (
+	Limit_Algorithm_name = map[int32]string{
+		0: "TOKEN_BUCKET",
+		1: "SLIDING_WINDOW",
+	}
+	Limit_Algorithm_value = map[string]int32{
+		"TOKEN_BUCKET":   0,
+		"SLIDING_WINDOW": 1,
+	}
+)
+
+func (x Limit_Algorithm) Enum() *Limit_Algorithm {
+	p := new(Limit_Algorithm)
+	*p = x
+	return p
+}
+
+func (x Limit_Algorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Limit_Algorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_enumTypes[0].Descriptor()
+}
+
+func (Limit_Algorithm) Type() protoreflect.EnumType {
+	return &file_gateway_middleware_ratelimit_v1_ratelimit_proto_enumTypes[0]
+}
+
+func (x Limit_Algorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Limit_Algorithm.Descriptor instead.
+func (Limit_Algorithm) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{5, 0}
+}
+
+// RateLimit middleware config.
+type RateLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the request is rejected if any of the limits is exceeded
+	Limits []*Limit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	// do not reply the X-RateLimit-* headers
+	DisableResponseHeaders bool `protobuf:"varint,2,opt,name=disable_response_headers,json=disableResponseHeaders,proto3" json:"disable_response_headers,omitempty"`
+	// share the counters across the gateway instances, the limits are counted by
+	// the sliding window in the store, the local limits are used when the store is unreachable
+	Store *Store `protobuf:"bytes,3,opt,name=store,proto3" json:"store,omitempty"`
+}
+
+func (x *RateLimit) Reset() {
+	*x = RateLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimit) ProtoMessage() {}
+
+func (x *RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimit.ProtoReflect.Descriptor instead.
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RateLimit) GetLimits() []*Limit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *RateLimit) GetDisableResponseHeaders() bool {
+	if x != nil {
+		return x.DisableResponseHeaders
+	}
+	return false
+}
+
+func (x *RateLimit) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+type Store struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Backend:
+	//
+	//	*Store_Memory
+	//	*Store_Redis
+	//	*Store_Peer
+	Backend isStore_Backend `protobuf_oneof:"backend"`
+	// the timeout of the store operations, default is 50ms
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// how long the local limits are used after the store fails, default is 5s
+	FallbackDuration *durationpb.Duration `protobuf:"bytes,5,opt,name=fallback_duration,json=fallbackDuration,proto3" json:"fallback_duration,omitempty"`
+	// default is gateway:ratelimit:
+	KeyPrefix string `protobuf:"bytes,6,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+}
+
+func (x *Store) Reset() {
+	*x = Store{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Store) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Store) ProtoMessage() {}
+
+func (x *Store) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Store.ProtoReflect.Descriptor instead.
+func (*Store) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *Store) GetBackend() isStore_Backend {
+	if m != nil {
+		return m.Backend
+	}
+	return nil
+}
+
+func (x *Store) GetMemory() *MemoryStore {
+	if x, ok := x.GetBackend().(*Store_Memory); ok {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *Store) GetRedis() *RedisStore {
+	if x, ok := x.GetBackend().(*Store_Redis); ok {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *Store) GetPeer() *PeerStore {
+	if x, ok := x.GetBackend().(*Store_Peer); ok {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *Store) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Store) GetFallbackDuration() *durationpb.Duration {
+	if x != nil {
+		return x.FallbackDuration
+	}
+	return nil
+}
+
+func (x *Store) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+type isStore_Backend interface {
+	isStore_Backend()
+}
+
+type Store_Memory struct {
+	Memory *MemoryStore `protobuf:"bytes,1,opt,name=memory,proto3,oneof"`
+}
+
+type Store_Redis struct {
+	Redis *RedisStore `protobuf:"bytes,2,opt,name=redis,proto3,oneof"`
+}
+
+type Store_Peer struct {
+	Peer *PeerStore `protobuf:"bytes,3,opt,name=peer,proto3,oneof"`
+}
+
+func (*Store_Memory) isStore_Backend() {}
+
+func (*Store_Redis) isStore_Backend() {}
+
+func (*Store_Peer) isStore_Backend() {}
+
+// MemoryStore shares the counters in the process only.
+type MemoryStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MemoryStore) Reset() {
+	*x = MemoryStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryStore) ProtoMessage() {}
+
+func (x *MemoryStore) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryStore.ProtoReflect.Descriptor instead.
+func (*MemoryStore) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{2}
+}
+
+// RedisStore shares the counters in a Redis protocol compatible server.
+type RedisStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// eg: 127.0.0.1:6379
+	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Db       int32  `protobuf:"varint,4,opt,name=db,proto3" json:"db,omitempty"`
+	// the max idle connections, default is 16
+	PoolSize int32 `protobuf:"varint,5,opt,name=pool_size,json=poolSize,proto3" json:"pool_size,omitempty"`
+}
+
+func (x *RedisStore) Reset() {
+	*x = RedisStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedisStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisStore) ProtoMessage() {}
+
+func (x *RedisStore) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisStore.ProtoReflect.Descriptor instead.
+func (*RedisStore) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RedisStore) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RedisStore) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RedisStore) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RedisStore) GetDb() int32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *RedisStore) GetPoolSize() int32 {
+	if x != nil {
+		return x.PoolSize
+	}
+	return 0
+}
+
+// PeerStore exchanges the counters with the other gateway instances periodically,
+// the counters are received by the peer listener of the gateway, see -ratelimit.peer.addr.
+type PeerStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the peer addresses, default is the instances of ctrl.name in discovery
+	// with the port of the peer listener
+	Peers []string `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	// default is 1s
+	SyncInterval *durationpb.Duration `protobuf:"bytes,3,opt,name=sync_interval,json=syncInterval,proto3" json:"sync_interval,omitempty"`
+	// the shared secret to authenticate the peers, it's sent in the request header,
+	// so the peer listener should be served with tls out of the trusted network.
+	// required unless the peer server verifies the client certificates
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *PeerStore) Reset() {
+	*x = PeerStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStore) ProtoMessage() {}
+
+func (x *PeerStore) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStore.ProtoReflect.Descriptor instead.
+func (*PeerStore) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PeerStore) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *PeerStore) GetSyncInterval() *durationpb.Duration {
+	if x != nil {
+		return x.SyncInterval
+	}
+	return nil
+}
+
+func (x *PeerStore) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type Limit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the name of the limit in metrics
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the requests are counted separately by the key, empty means all requests of the endpoint
+	Key *Key `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// the allowed requests in the period
+	Requests int64 `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	// default is 1s
+	Period    *durationpb.Duration `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	Algorithm Limit_Algorithm      `protobuf:"varint,5,opt,name=algorithm,proto3,enum=gateway.middleware.ratelimit.v1.Limit_Algorithm" json:"algorithm,omitempty"`
+	// the bucket capacity of TOKEN_BUCKET, default is requests
+	Burst int64 `protobuf:"varint,6,opt,name=burst,proto3" json:"burst,omitempty"`
+}
+
+func (x *Limit) Reset() {
+	*x = Limit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limit) ProtoMessage() {}
+
+func (x *Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limit.ProtoReflect.Descriptor instead.
+func (*Limit) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Limit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Limit) GetKey() *Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Limit) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *Limit) GetPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *Limit) GetAlgorithm() Limit_Algorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return Limit_TOKEN_BUCKET
+}
+
+func (x *Limit) GetBurst() int64 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+// Key is where the rate limit key is from, the limit is skipped if the key is absent.
+type Key struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Source:
+	//
+	//	*Key_ClientIp
+	//	*Key_Header
+	//	*Key_JwtClaim
+	//	*Key_PathVariable
+	Source isKey_Source `protobuf_oneof:"source"`
+}
+
+func (x *Key) Reset() {
+	*x = Key{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Key) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Key) ProtoMessage() {}
+
+func (x *Key) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Key.ProtoReflect.Descriptor instead.
+func (*Key) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *Key) GetSource() isKey_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Key) GetClientIp() bool {
+	if x, ok := x.GetSource().(*Key_ClientIp); ok {
+		return x.ClientIp
+	}
+	return false
+}
+
+func (x *Key) GetHeader() string {
+	if x, ok := x.GetSource().(*Key_Header); ok {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *Key) GetJwtClaim() string {
+	if x, ok := x.GetSource().(*Key_JwtClaim); ok {
+		return x.JwtClaim
+	}
+	return ""
+}
+
+func (x *Key) GetPathVariable() string {
+	if x, ok := x.GetSource().(*Key_PathVariable); ok {
+		return x.PathVariable
+	}
+	return ""
+}
+
+type isKey_Source interface {
+	isKey_Source()
+}
+
+type Key_ClientIp struct {
+	// the remote address of the connection
+	ClientIp bool `protobuf:"varint,1,opt,name=client_ip,json=clientIp,proto3,oneof"`
+}
+
+type Key_Header struct {
+	Header string `protobuf:"bytes,2,opt,name=header,proto3,oneof"`
+}
+
+type Key_JwtClaim struct {
+	// the claim of the token verified by the jwt middleware, eg: sub
+	JwtClaim string `protobuf:"bytes,3,opt,name=jwt_claim,json=jwtClaim,proto3,oneof"`
+}
+
+type Key_PathVariable struct {
+	// the variable in the endpoint path, eg: id in /api/users/{id}
+	PathVariable string `protobuf:"bytes,4,opt,name=path_variable,json=pathVariable,proto3,oneof"`
+}
+
+func (*Key_ClientIp) isKey_Source() {}
+
+func (*Key_Header) isKey_Source() {}
+
+func (*Key_JwtClaim) isKey_Source() {}
+
+func (*Key_PathVariable) isKey_Source() {}
+
+var File_gateway_middleware_ratelimit_v1_ratelimit_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDesc = []byte{
+	0x0a, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2f, 0x76,
+	0x31, 0x2f, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x1f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e,
+	0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xc3, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x3e, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x12, 0x38, 0x0a, 0x18, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x16, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x05, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72,
+	0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xfd, 0x02, 0x0a, 0x05, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x46, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64,
+	0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x48, 0x00, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x05, 0x72, 0x65,
+	0x64, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72,
+	0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x69,
+	0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73, 0x12,
+	0x40, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x04, 0x70, 0x65, 0x65,
+	0x72, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x46, 0x0a, 0x11, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61,
+	0x63, 0x6b, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x66, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x42, 0x09, 0x0a,
+	0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x0d, 0x0a, 0x0b, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x64, 0x69,
+	0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x64, 0x62, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x6f, 0x6c,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x6f,
+	0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x73, 0x79, 0x6e,
+	0x63, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x79, 0x6e,
+	0x63, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x22, 0xbb, 0x02, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x36, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2e, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x4e, 0x0a, 0x09, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x2e, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x09,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72,
+	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x22,
+	0x31, 0x0a, 0x09, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x10, 0x0a, 0x0c,
+	0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x42, 0x55, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x4c, 0x49, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57,
+	0x10, 0x01, 0x22, 0x8e, 0x01, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x09, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x70, 0x12, 0x18, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x09, 0x6a, 0x77, 0x74, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x6a, 0x77, 0x74, 0x43, 0x6c, 0x61,
+	0x69, 0x6d, 0x12, 0x25, 0x0a, 0x0d, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x70, 0x61, 0x74,
+	0x68, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f,
+	0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x72, 0x61, 0x74, 0x65, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescOnce sync.Once
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescData = file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDesc
+)
+
+func file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescData)
+	})
+	return file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDescData
+}
+
+var file_gateway_middleware_ratelimit_v1_ratelimit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_gateway_middleware_ratelimit_v1_ratelimit_proto_goTypes = []interface{}{
+	(Limit_Algorithm)(0),        // 0: gateway.middleware.ratelimit.v1.Limit.Algorithm
+	(*RateLimit)(nil),           // 1: gateway.middleware.ratelimit.v1.RateLimit
+	(*Store)(nil),               // 2: gateway.middleware.ratelimit.v1.Store
+	(*MemoryStore)(nil),         // 3: gateway.middleware.ratelimit.v1.MemoryStore
+	(*RedisStore)(nil),          // 4: gateway.middleware.ratelimit.v1.RedisStore
+	(*PeerStore)(nil),           // 5: gateway.middleware.ratelimit.v1.PeerStore
+	(*Limit)(nil),               // 6: gateway.middleware.ratelimit.v1.Limit
+	(*Key)(nil),                 // 7: gateway.middleware.ratelimit.v1.Key
+	(*durationpb.Duration)(nil), // 8: google.protobuf.Duration
+}
+var file_gateway_middleware_ratelimit_v1_ratelimit_proto_depIdxs = []int32{
+	6,  // 0: gateway.middleware.ratelimit.v1.RateLimit.limits:type_name -> gateway.middleware.ratelimit.v1.Limit
+	2,  // 1: gateway.middleware.ratelimit.v1.RateLimit.store:type_name -> gateway.middleware.ratelimit.v1.Store
+	3,  // 2: gateway.middleware.ratelimit.v1.Store.memory:type_name -> gateway.middleware.ratelimit.v1.MemoryStore
+	4,  // 3: gateway.middleware.ratelimit.v1.Store.redis:type_name -> gateway.middleware.ratelimit.v1.RedisStore
+	5,  // 4: gateway.middleware.ratelimit.v1.Store.peer:type_name -> gateway.middleware.ratelimit.v1.PeerStore
+	8,  // 5: gateway.middleware.ratelimit.v1.Store.timeout:type_name -> google.protobuf.Duration
+	8,  // 6: gateway.middleware.ratelimit.v1.Store.fallback_duration:type_name -> google.protobuf.Duration
+	8,  // 7: gateway.middleware.ratelimit.v1.PeerStore.sync_interval:type_name -> google.protobuf.Duration
+	7,  // 8: gateway.middleware.ratelimit.v1.Limit.key:type_name -> gateway.middleware.ratelimit.v1.Key
+	8,  // 9: gateway.middleware.ratelimit.v1.Limit.period:type_name -> google.protobuf.Duration
+	0,  // 10: gateway.middleware.ratelimit.v1.Limit.algorithm:type_name -> gateway.middleware.ratelimit.v1.Limit.Algorithm
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_ratelimit_v1_ratelimit_proto_init() }
+func file_gateway_middleware_ratelimit_v1_ratelimit_proto_init() {
+	if File_gateway_middleware_ratelimit_v1_ratelimit_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Store); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemoryStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedisStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Limit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Key); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Store_Memory)(nil),
+		(*Store_Redis)(nil),
+		(*Store_Peer)(nil),
+	}
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*Key_ClientIp)(nil),
+		(*Key_Header)(nil),
+		(*Key_JwtClaim)(nil),
+		(*Key_PathVariable)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_ratelimit_v1_ratelimit_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_ratelimit_v1_ratelimit_proto_depIdxs,
+		EnumInfos:         file_gateway_middleware_ratelimit_v1_ratelimit_proto_enumTypes,
+		MessageInfos:      file_gateway_middleware_ratelimit_v1_ratelimit_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_ratelimit_v1_ratelimit_proto = out.File
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_rawDesc = nil
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_goTypes = nil
+	file_gateway_middleware_ratelimit_v1_ratelimit_proto_depIdxs = nil
+}
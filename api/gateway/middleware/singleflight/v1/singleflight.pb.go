@@ -0,0 +1,186 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: gateway/middleware/singleflight/v1/singleflight.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Singleflight middleware config.
+type Singleflight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the idempotent methods to collapse, default is GET and HEAD
+	Methods []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	// the request headers which are part of the key, Authorization and Cookie are always included
+	KeyHeaders []string `protobuf:"bytes,2,rep,name=key_headers,json=keyHeaders,proto3" json:"key_headers,omitempty"`
+	// do not include the query in the key
+	IgnoreQuery bool `protobuf:"varint,3,opt,name=ignore_query,json=ignoreQuery,proto3" json:"ignore_query,omitempty"`
+	// the responses larger than it are not shared, the waiters send their own requests,
+	// default is 4MiB
+	MaxBodySize int64 `protobuf:"varint,4,opt,name=max_body_size,json=maxBodySize,proto3" json:"max_body_size,omitempty"`
+}
+
+func (x *Singleflight) Reset() {
+	*x = Singleflight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_singleflight_v1_singleflight_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Singleflight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Singleflight) ProtoMessage() {}
+
+func (x *Singleflight) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_singleflight_v1_singleflight_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Singleflight.ProtoReflect.Descriptor instead.
+func (*Singleflight) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Singleflight) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *Singleflight) GetKeyHeaders() []string {
+	if x != nil {
+		return x.KeyHeaders
+	}
+	return nil
+}
+
+func (x *Singleflight) GetIgnoreQuery() bool {
+	if x != nil {
+		return x.IgnoreQuery
+	}
+	return false
+}
+
+func (x *Singleflight) GetMaxBodySize() int64 {
+	if x != nil {
+		return x.MaxBodySize
+	}
+	return 0
+}
+
+var File_gateway_middleware_singleflight_v1_singleflight_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_singleflight_v1_singleflight_proto_rawDesc = []byte{
+	0x0a, 0x35, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x22, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x73, 0x69, 0x6e, 0x67,
+	0x6c, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x76, 0x31, 0x22, 0x90, 0x01, 0x0a, 0x0c,
+	0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x65, 0x79,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69,
+	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61,
+	0x78, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x45,
+	0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d,
+	0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x66, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescOnce sync.Once
+	file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescData = file_gateway_middleware_singleflight_v1_singleflight_proto_rawDesc
+)
+
+func file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescData)
+	})
+	return file_gateway_middleware_singleflight_v1_singleflight_proto_rawDescData
+}
+
+var file_gateway_middleware_singleflight_v1_singleflight_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_singleflight_v1_singleflight_proto_goTypes = []interface{}{
+	(*Singleflight)(nil), // 0: gateway.middleware.singleflight.v1.Singleflight
+}
+var file_gateway_middleware_singleflight_v1_singleflight_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_singleflight_v1_singleflight_proto_init() }
+func file_gateway_middleware_singleflight_v1_singleflight_proto_init() {
+	if File_gateway_middleware_singleflight_v1_singleflight_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_singleflight_v1_singleflight_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Singleflight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_singleflight_v1_singleflight_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_singleflight_v1_singleflight_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_singleflight_v1_singleflight_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_singleflight_v1_singleflight_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_singleflight_v1_singleflight_proto = out.File
+	file_gateway_middleware_singleflight_v1_singleflight_proto_rawDesc = nil
+	file_gateway_middleware_singleflight_v1_singleflight_proto_goTypes = nil
+	file_gateway_middleware_singleflight_v1_singleflight_proto_depIdxs = nil
+}
@@ -0,0 +1,368 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/selector/node/direct"
+	"github.com/go-kratos/kratos/v2/selector/p2c"
+	"github.com/go-kratos/kratos/v2/selector/random"
+	"github.com/go-kratos/kratos/v2/selector/wrr"
+)
+
+const (
+	_defaultVirtualNodes = 160
+	_maxVirtualNodes     = 10000
+	_maglevTableSize     = 65537
+	_maxCachedTables     = 8
+	_hashSeedPosition    = "position"
+	_hashSeedSkip        = "skip"
+)
+
+type hashKeyContextKey struct{}
+
+func withHashKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, hashKeyContextKey{}, key)
+}
+
+func hashKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(hashKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// newPickerBuilder returns the picker builder of the load balancer.
+func newPickerBuilder(lb *config.LoadBalancer) (selector.Builder, error) {
+	switch lb.Policy {
+	case config.LoadBalancer_P2C:
+		return p2c.NewBuilder(), nil
+	case config.LoadBalancer_ROUND_ROBIN:
+		return &selector.DefaultBuilder{Node: &direct.Builder{}, Balancer: balancerBuilder(func() selector.Balancer {
+			return &roundRobinBalancer{}
+		})}, nil
+	case config.LoadBalancer_WEIGHTED_ROUND_ROBIN:
+		return wrr.NewBuilder(), nil
+	case config.LoadBalancer_LEAST_REQUEST:
+		return &selector.DefaultBuilder{Node: &activeNodeBuilder{}, Balancer: balancerBuilder(func() selector.Balancer {
+			return &leastRequestBalancer{}
+		})}, nil
+	case config.LoadBalancer_RANDOM:
+		return random.NewBuilder(), nil
+	case config.LoadBalancer_RING_HASH, config.LoadBalancer_MAGLEV:
+		if len(lb.HashPolicies) == 0 {
+			return nil, fmt.Errorf("hash policies are required by the %s load balancer", lb.Policy)
+		}
+		build := newMaglevTable
+		if lb.Policy == config.LoadBalancer_RING_HASH {
+			virtualNodes := lb.VirtualNodes
+			if virtualNodes == 0 {
+				virtualNodes = _defaultVirtualNodes
+			}
+			if virtualNodes > _maxVirtualNodes {
+				return nil, fmt.Errorf("virtual nodes exceed the limit %d: %d", _maxVirtualNodes, virtualNodes)
+			}
+			build = func(nodes []selector.WeightedNode) hashTable { return newRingTable(nodes, virtualNodes) }
+		}
+		return &selector.DefaultBuilder{Node: &direct.Builder{}, Balancer: balancerBuilder(func() selector.Balancer {
+			return &hashBalancer{build: build, tables: make(map[string]hashTable)}
+		})}, nil
+	default:
+		return nil, fmt.Errorf("unknown load balancer policy: %s", lb.Policy)
+	}
+}
+
+// newHashKey returns the function to extract the hash key of the request, it's nil without hash policies.
+func newHashKey(lb *config.LoadBalancer) func(*http.Request) string {
+	switch lb.GetPolicy() {
+	case config.LoadBalancer_RING_HASH, config.LoadBalancer_MAGLEV:
+	default:
+		return nil
+	}
+	keys := make([]func(*http.Request) string, 0, len(lb.HashPolicies))
+	for _, p := range lb.HashPolicies {
+		switch key := p.Key.(type) {
+		case *config.HashPolicy_Header:
+			keys = append(keys, func(req *http.Request) string { return req.Header.Get(key.Header) })
+		case *config.HashPolicy_Cookie:
+			keys = append(keys, func(req *http.Request) string {
+				cookie, err := req.Cookie(key.Cookie)
+				if err != nil {
+					return ""
+				}
+				return cookie.Value
+			})
+		case *config.HashPolicy_SourceIp:
+			if !key.SourceIp {
+				continue
+			}
+			keys = append(keys, func(req *http.Request) string {
+				ip, _, err := net.SplitHostPort(req.RemoteAddr)
+				if err != nil {
+					return req.RemoteAddr
+				}
+				return ip
+			})
+		}
+	}
+	return func(req *http.Request) string {
+		for _, key := range keys {
+			if v := key(req); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+}
+
+type balancerBuilder func() selector.Balancer
+
+func (b balancerBuilder) Build() selector.Balancer { return b() }
+
+type roundRobinBalancer struct {
+	next atomic.Uint64
+}
+
+func (b *roundRobinBalancer) Pick(_ context.Context, nodes []selector.WeightedNode) (selector.WeightedNode, selector.DoneFunc, error) {
+	if len(nodes) == 0 {
+		return nil, nil, selector.ErrNoAvailable
+	}
+	selected := nodes[(b.next.Add(1)-1)%uint64(len(nodes))]
+	return selected, selected.Pick(), nil
+}
+
+// activeNode counts the active requests of the node.
+type activeNode struct {
+	*direct.Node
+	active atomic.Int64
+}
+
+func (n *activeNode) Pick() selector.DoneFunc {
+	n.Node.Pick()
+	n.active.Add(1)
+	var once sync.Once
+	return func(context.Context, selector.DoneInfo) {
+		once.Do(func() { n.active.Add(-1) })
+	}
+}
+
+type activeNodeBuilder struct {
+	direct.Builder
+}
+
+func (b *activeNodeBuilder) Build(n selector.Node) selector.WeightedNode {
+	return &activeNode{Node: b.Builder.Build(n).(*direct.Node)}
+}
+
+// leastRequestBalancer picks the node with fewer active requests per weight of two random choices.
+type leastRequestBalancer struct{}
+
+func (b *leastRequestBalancer) Pick(_ context.Context, nodes []selector.WeightedNode) (selector.WeightedNode, selector.DoneFunc, error) {
+	if len(nodes) == 0 {
+		return nil, nil, selector.ErrNoAvailable
+	}
+	selected := nodes[0]
+	if len(nodes) > 1 {
+		i := rand.IntN(len(nodes))
+		j := rand.IntN(len(nodes) - 1)
+		if j >= i {
+			j++
+		}
+		selected = nodes[i]
+		if load(nodes[j]) < load(selected) {
+			selected = nodes[j]
+		}
+	}
+	return selected, selected.Pick(), nil
+}
+
+func load(n selector.WeightedNode) float64 {
+	var active int64
+	if an, ok := n.(*activeNode); ok {
+		active = an.active.Load()
+	}
+	weight := n.Weight()
+	if weight <= 0 {
+		return math.Inf(1)
+	}
+	return float64(active+1) / weight
+}
+
+// hashTable maps the hash of the key to the index of the node.
+type hashTable interface {
+	lookup(hash uint64) int
+}
+
+// hashBalancer picks the node by the consistent hashing of the request key,
+// the tables are cached by the candidates since the nodes may be filtered per request.
+type hashBalancer struct {
+	build  func([]selector.WeightedNode) hashTable
+	lock   sync.Mutex
+	tables map[string]hashTable
+}
+
+func (b *hashBalancer) table(nodes []selector.WeightedNode) hashTable {
+	var key strings.Builder
+	for _, n := range nodes {
+		key.WriteString(n.Address())
+		key.WriteByte(',')
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if t, ok := b.tables[key.String()]; ok {
+		return t
+	}
+	if len(b.tables) >= _maxCachedTables {
+		clear(b.tables)
+	}
+	t := b.build(nodes)
+	b.tables[key.String()] = t
+	return t
+}
+
+func (b *hashBalancer) Pick(ctx context.Context, nodes []selector.WeightedNode) (selector.WeightedNode, selector.DoneFunc, error) {
+	if len(nodes) == 0 {
+		return nil, nil, selector.ErrNoAvailable
+	}
+	key, ok := hashKeyFromContext(ctx)
+	if !ok {
+		selected := nodes[rand.IntN(len(nodes))]
+		return selected, selected.Pick(), nil
+	}
+	i := b.table(nodes).lookup(hash64(key, ""))
+	if i < 0 {
+		return nil, nil, errors.New("no node with positive weight")
+	}
+	selected := nodes[i]
+	return selected, selected.Pick(), nil
+}
+
+// hash64 returns the fnv-1a hash of the key with the seed, finalized to spread the bits.
+func hash64(key, seed string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+type ringEntry struct {
+	hash  uint64
+	index int
+}
+
+type ringTable []ringEntry
+
+// newRingTable places the virtual nodes on the ring in proportion to the weights,
+// the virtual nodes of a node don't depend on the others to keep the keys in place.
+func newRingTable(nodes []selector.WeightedNode, virtualNodes uint64) hashTable {
+	var maxWeight float64
+	for _, n := range nodes {
+		maxWeight = math.Max(maxWeight, n.Weight())
+	}
+	if maxWeight <= 0 {
+		return ringTable(nil)
+	}
+	ring := make(ringTable, 0, uint64(len(nodes))*virtualNodes)
+	for i, n := range nodes {
+		if n.Weight() <= 0 {
+			continue
+		}
+		replicas := uint64(math.Ceil(float64(virtualNodes) * n.Weight() / maxWeight))
+		for r := uint64(0); r < replicas; r++ {
+			ring = append(ring, ringEntry{hash: hash64(fmt.Sprintf("%s_%d", n.Address(), r), _hashSeedPosition), index: i})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool { return ring[i].hash < ring[j].hash })
+	return ring
+}
+
+func (r ringTable) lookup(hash uint64) int {
+	if len(r) == 0 {
+		return -1
+	}
+	i := sort.Search(len(r), func(i int) bool { return r[i].hash >= hash })
+	if i == len(r) {
+		i = 0
+	}
+	return r[i].index
+}
+
+type maglevTable []int32
+
+// newMaglevTable populates the lookup table by the preference lists of the nodes,
+// the nodes of lower weight skip their turns in proportion.
+func newMaglevTable(nodes []selector.WeightedNode) hashTable {
+	type entry struct {
+		index  int
+		offset uint64
+		skip   uint64
+		weight float64
+		target float64
+		next   uint64
+	}
+	var maxWeight float64
+	for _, n := range nodes {
+		maxWeight = math.Max(maxWeight, n.Weight())
+	}
+	if maxWeight <= 0 {
+		return maglevTable(nil)
+	}
+	entries := make([]*entry, 0, len(nodes))
+	for i, n := range nodes {
+		if n.Weight() <= 0 {
+			continue
+		}
+		entries = append(entries, &entry{
+			index:  i,
+			offset: hash64(n.Address(), _hashSeedPosition) % _maglevTableSize,
+			skip:   hash64(n.Address(), _hashSeedSkip)%(_maglevTableSize-1) + 1,
+			weight: n.Weight() / maxWeight,
+		})
+	}
+	table := make(maglevTable, _maglevTableSize)
+	for i := range table {
+		table[i] = -1
+	}
+	for filled, iteration := 0, 1; filled < _maglevTableSize; iteration++ {
+		for _, e := range entries {
+			if float64(iteration)*e.weight < e.target {
+				continue
+			}
+			e.target++
+			c := (e.offset + e.skip*e.next) % _maglevTableSize
+			for table[c] >= 0 {
+				e.next++
+				c = (e.offset + e.skip*e.next) % _maglevTableSize
+			}
+			table[c] = int32(e.index)
+			e.next++
+			if filled++; filled == _maglevTableSize {
+				break
+			}
+		}
+	}
+	return table
+}
+
+func (t maglevTable) lookup(hash uint64) int {
+	if len(t) == 0 {
+		return -1
+	}
+	return int(t[hash%uint64(len(t))])
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+func newTestSelector(t *testing.T, lb *config.LoadBalancer, addrs ...string) selector.Selector {
+	builder, err := newPickerBuilder(lb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := builder.Build()
+	nodes := make([]selector.Node, 0, len(addrs))
+	for _, addr := range addrs {
+		nodes = append(nodes, selector.NewNode("http", addr, &registry.ServiceInstance{}))
+	}
+	s.Apply(nodes)
+	return s
+}
+
+func pick(t *testing.T, s selector.Selector, key string) (string, selector.DoneFunc) {
+	ctx := context.Background()
+	if key != "" {
+		ctx = withHashKey(ctx, key)
+	}
+	n, done, err := s.Select(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n.Address(), done
+}
+
+func TestRoundRobin(t *testing.T) {
+	s := newTestSelector(t, &config.LoadBalancer{Policy: config.LoadBalancer_ROUND_ROBIN}, "a", "b", "c")
+	counts := make(map[string]int)
+	for i := 0; i < 30; i++ {
+		addr, _ := pick(t, s, "")
+		counts[addr]++
+	}
+	for _, addr := range []string{"a", "b", "c"} {
+		if counts[addr] != 10 {
+			t.Fatalf("expected the requests to be balanced evenly, got %v", counts)
+		}
+	}
+}
+
+func TestLeastRequest(t *testing.T) {
+	s := newTestSelector(t, &config.LoadBalancer{Policy: config.LoadBalancer_LEAST_REQUEST}, "a", "b")
+	busy, done := pick(t, s, "")
+	for i := 0; i < 10; i++ {
+		addr, d := pick(t, s, "")
+		if addr == busy {
+			t.Fatalf("expected the idle node to be picked, got %s", addr)
+		}
+		d(context.Background(), selector.DoneInfo{})
+	}
+	done(context.Background(), selector.DoneInfo{})
+}
+
+func TestConsistentHash(t *testing.T) {
+	for _, policy := range []config.LoadBalancer_Policy{config.LoadBalancer_RING_HASH, config.LoadBalancer_MAGLEV} {
+		t.Run(policy.String(), func(t *testing.T) {
+			lb := &config.LoadBalancer{Policy: policy, HashPolicies: []*config.HashPolicy{
+				{Key: &config.HashPolicy_Header{Header: "X-User"}},
+			}}
+			s := newTestSelector(t, lb, "a", "b", "c", "d")
+			assigned := make(map[string]string)
+			counts := make(map[string]int)
+			for i := 0; i < 1000; i++ {
+				key := fmt.Sprintf("user-%d", i)
+				addr, _ := pick(t, s, key)
+				if again, _ := pick(t, s, key); again != addr {
+					t.Fatalf("expected key %s to be hashed to %s, got %s", key, addr, again)
+				}
+				assigned[key] = addr
+				counts[addr]++
+			}
+			for addr, n := range counts {
+				if n < 150 || n > 350 {
+					t.Fatalf("expected about a quarter of the keys on %s, got %d", addr, n)
+				}
+			}
+			// only the keys of the removed node are remapped
+			s.Apply([]selector.Node{
+				selector.NewNode("http", "a", &registry.ServiceInstance{}),
+				selector.NewNode("http", "b", &registry.ServiceInstance{}),
+				selector.NewNode("http", "c", &registry.ServiceInstance{}),
+			})
+			moved := 0
+			for key, prev := range assigned {
+				if addr, _ := pick(t, s, key); addr != prev {
+					if prev != "d" {
+						moved++
+					}
+				}
+			}
+			if moved > 50 {
+				t.Fatalf("expected few keys of the remaining nodes to be remapped, got %d", moved)
+			}
+		})
+	}
+}
+
+func TestHashPoliciesRequired(t *testing.T) {
+	if _, err := newPickerBuilder(&config.LoadBalancer{Policy: config.LoadBalancer_MAGLEV}); err == nil {
+		t.Fatal("expected error without hash policies")
+	}
+}
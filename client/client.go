@@ -12,6 +12,8 @@ import (
 type client struct {
 	applier  *nodeApplier
 	selector selector.Selector
+	hashKey  func(*http.Request) string
+	locality selector.NodeFilter
 }
 
 type Client interface {
@@ -20,10 +22,15 @@ type Client interface {
 }
 
 func newClient(applier *nodeApplier, selector selector.Selector) *client {
-	return &client{
+	c := &client{
 		applier:  applier,
 		selector: selector,
+		hashKey:  newHashKey(applier.endpoint.LoadBalancer),
 	}
+	if applier.locality != nil {
+		c.locality = applier.locality.filter(applier.localState.Load)
+	}
+	return c
 }
 
 func (c *client) Close() error {
@@ -35,7 +42,15 @@ func (c *client) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 	ctx := req.Context()
 	reqOpt, _ := middleware.FromRequestContext(ctx)
 	filter, _ := middleware.SelectorFiltersFromContext(ctx)
-	n, done, err := c.selector.Select(ctx, selector.WithNodeFilter(filter...))
+	if c.locality != nil {
+		// the locality is preferred after the filters of the request
+		filter = append(filter[:len(filter):len(filter)], c.locality)
+	}
+	selectCtx := ctx
+	if c.hashKey != nil {
+		selectCtx = withHashKey(ctx, c.hashKey(req))
+	}
+	n, done, err := c.selector.Select(selectCtx, selector.WithNodeFilter(filter...))
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +58,9 @@ func (c *client) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 
 	addr := n.Address()
 	reqOpt.Backends = append(reqOpt.Backends, addr)
+	if reqOpt.Hedged != nil {
+		reqOpt.Hedged.Add(addr)
+	}
 	backendNode := n.(*node)
 	req.URL.Host = addr
 	req.URL.Scheme = "http"
@@ -58,11 +76,13 @@ func (c *client) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 	resp, err = backendNode.client.Do(req)
 	reqOpt.UpstreamResponseTime = append(reqOpt.UpstreamResponseTime, time.Since(startAt).Seconds())
 	if err != nil {
+		c.applier.outlier.report(addr, 0, err)
 		done(ctx, selector.DoneInfo{Err: err})
 		reqOpt.UpstreamStatusCode = append(reqOpt.UpstreamStatusCode, 0)
 		return nil, err
 	}
 	reqOpt.UpstreamStatusCode = append(reqOpt.UpstreamStatusCode, resp.StatusCode)
+	c.applier.outlier.report(addr, resp.StatusCode, nil)
 	reqOpt.DoneFunc = done
 	return resp, nil
 }
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	_metricClusterRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "cluster_requests_code_total",
+		Help:      "The total number of upstream requests by cluster",
+	}, []string{"protocol", "method", "path", "service", "basePath", "cluster", "code"})
+	_metricClusterRequestsDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "cluster_requests_duration_seconds",
+		Help:      "Upstream requests duration(sec) by cluster.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	}, []string{"protocol", "method", "path", "service", "basePath", "cluster"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricClusterRequestsTotal)
+	prometheus.MustRegister(_metricClusterRequestsDuration)
+}
+
+type cluster struct {
+	name   string
+	weight int64
+	client Client
+}
+
+// weightedClient splits the requests by weight across the clusters, each cluster has its own picker.
+type weightedClient struct {
+	clusters []*cluster
+	total    int64
+	sticky   func(*http.Request) string
+}
+
+func newWeightedClient(builderCtx *BuildContext, endpoint *config.Endpoint, factory Factory) (Client, error) {
+	wc := endpoint.WeightedClusters
+	c := &weightedClient{}
+	switch {
+	case wc.StickyHeader != "":
+		c.sticky = func(req *http.Request) string { return req.Header.Get(wc.StickyHeader) }
+	case wc.StickyCookie != "":
+		c.sticky = func(req *http.Request) string {
+			cookie, err := req.Cookie(wc.StickyCookie)
+			if err != nil {
+				return ""
+			}
+			return cookie.Value
+		}
+	}
+	for i, cl := range wc.Clusters {
+		if cl.Weight < 0 {
+			c.Close()
+			return nil, fmt.Errorf("invalid weight of cluster %q: %d", cl.Name, cl.Weight)
+		}
+		if cl.Weight == 0 {
+			continue
+		}
+		name := cl.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		// the cluster endpoint shares everything but the backends with the endpoint
+		clusterEndpoint := proto.Clone(endpoint).(*config.Endpoint)
+		clusterEndpoint.Backends = cl.Backends
+		clusterEndpoint.WeightedClusters = nil
+		client, err := factory(builderCtx, clusterEndpoint)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.clusters = append(c.clusters, &cluster{name: name, weight: cl.Weight, client: client})
+		c.total += cl.Weight
+	}
+	if c.total == 0 {
+		return nil, errors.New("no cluster with positive weight")
+	}
+	return c, nil
+}
+
+func (c *weightedClient) pick(req *http.Request) *cluster {
+	var n int64
+	if key := c.stickyKey(req); key != "" {
+		h := fnv.New64a()
+		io.WriteString(h, key)
+		n = int64(h.Sum64() % uint64(c.total))
+	} else {
+		n = rand.Int64N(c.total)
+	}
+	for _, cl := range c.clusters {
+		if n < cl.weight {
+			return cl
+		}
+		n -= cl.weight
+	}
+	return c.clusters[len(c.clusters)-1]
+}
+
+func (c *weightedClient) stickyKey(req *http.Request) string {
+	if c.sticky == nil {
+		return ""
+	}
+	return c.sticky(req)
+}
+
+func (c *weightedClient) RoundTrip(req *http.Request) (*http.Response, error) {
+	cl := c.pick(req)
+	startAt := time.Now()
+	resp, err := cl.client.RoundTrip(req)
+	if labels, ok := middleware.MetricsLabelsFromContext(req.Context()); ok {
+		code := 0
+		if err == nil {
+			code = resp.StatusCode
+		}
+		_metricClusterRequestsTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), cl.name, strconv.Itoa(code)).Inc()
+		_metricClusterRequestsDuration.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), cl.name).Observe(time.Since(startAt).Seconds())
+	}
+	return resp, err
+}
+
+func (c *weightedClient) Close() error {
+	var errs []error
+	for _, cl := range c.clusters {
+		errs = append(errs, cl.client.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+type clusterClient struct {
+	name  string
+	calls int
+}
+
+func (c *clusterClient) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Cluster": {c.name}}, Body: http.NoBody}, nil
+}
+
+func (c *clusterClient) Close() error { return nil }
+
+func newTestWeightedClient(t *testing.T, wc *config.WeightedClusters) (Client, map[string]*clusterClient) {
+	clients := make(map[string]*clusterClient)
+	factory := func(_ *BuildContext, endpoint *config.Endpoint) (Client, error) {
+		if endpoint.WeightedClusters != nil {
+			t.Fatal("expected the weighted clusters to be cleared from the cluster endpoint")
+		}
+		c := &clusterClient{name: endpoint.Backends[0].Target}
+		clients[c.name] = c
+		return c, nil
+	}
+	c, err := newWeightedClient(EmptyBuildContext(), &config.Endpoint{Path: "/", WeightedClusters: wc}, factory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, clients
+}
+
+func TestWeightedClusters(t *testing.T) {
+	c, clients := newTestWeightedClient(t, &config.WeightedClusters{Clusters: []*config.Cluster{
+		{Name: "stable", Weight: 90, Backends: []*config.Backend{{Target: "discovery:///stable"}}},
+		{Name: "canary", Weight: 10, Backends: []*config.Backend{{Target: "discovery:///canary"}}},
+		{Name: "disabled", Weight: 0, Backends: []*config.Backend{{Target: "discovery:///disabled"}}},
+	}})
+	for i := 0; i < 10000; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		if _, err := c.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := clients["discovery:///disabled"]; ok {
+		t.Fatal("expected no client for the cluster of zero weight")
+	}
+	if n := clients["discovery:///canary"].calls; n < 800 || n > 1200 {
+		t.Fatalf("expected about 10%% of the requests to the canary cluster, got %d", n)
+	}
+}
+
+func TestWeightedClustersSticky(t *testing.T) {
+	c, _ := newTestWeightedClient(t, &config.WeightedClusters{StickyHeader: "X-User", Clusters: []*config.Cluster{
+		{Name: "a", Weight: 1, Backends: []*config.Backend{{Target: "discovery:///a"}}},
+		{Name: "b", Weight: 1, Backends: []*config.Backend{{Target: "discovery:///b"}}},
+	}})
+	seen := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		user := string(rune('a' + i%10))
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		req.Header.Set("X-User", user)
+		resp, err := c.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cluster := resp.Header.Get("X-Cluster")
+		if prev, ok := seen[user]; ok && prev != cluster {
+			t.Fatalf("expected user %s to stick to %s, got %s", user, prev, cluster)
+		}
+		seen[user] = cluster
+	}
+}
+
+func TestWeightedClustersInvalid(t *testing.T) {
+	factory := func(*BuildContext, *config.Endpoint) (Client, error) { return &clusterClient{}, nil }
+	_, err := newWeightedClient(EmptyBuildContext(), &config.Endpoint{WeightedClusters: &config.WeightedClusters{Clusters: []*config.Cluster{
+		{Name: "a", Weight: 0},
+	}}}, factory)
+	if err == nil {
+		t.Fatal("expected error without any cluster of positive weight")
+	}
+}
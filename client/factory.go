@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
@@ -28,6 +29,7 @@ type Factory func(*BuildContext, *config.Endpoint) (Client, error)
 type Option func(*options)
 type options struct {
 	pickerBuilder selector.Builder
+	locality      Locality
 }
 
 func WithPickerBuilder(in selector.Builder) Option {
@@ -77,8 +79,19 @@ func NewFactory(r registry.Discovery, opts ...Option) Factory {
 	for _, opt := range opts {
 		opt(o)
 	}
-	return func(builderCtx *BuildContext, endpoint *config.Endpoint) (Client, error) {
-		picker := o.pickerBuilder.Build()
+	var factory Factory
+	factory = func(builderCtx *BuildContext, endpoint *config.Endpoint) (Client, error) {
+		if len(endpoint.GetWeightedClusters().GetClusters()) > 0 {
+			return newWeightedClient(builderCtx, endpoint, factory)
+		}
+		pickerBuilder := o.pickerBuilder
+		if endpoint.LoadBalancer != nil {
+			var err error
+			if pickerBuilder, err = newPickerBuilder(endpoint.LoadBalancer); err != nil {
+				return nil, err
+			}
+		}
+		picker := pickerBuilder.Build()
 		ctx, cancel := context.WithCancel(context.Background())
 		applier := &nodeApplier{
 			cancel:       cancel,
@@ -86,13 +99,20 @@ func NewFactory(r registry.Discovery, opts ...Option) Factory {
 			registry:     r,
 			picker:       picker,
 			buildContext: builderCtx,
+			backendNodes: make([][]*node, len(endpoint.Backends)),
+			locality:     newLocalityBalancer(o.locality, endpoint.Locality),
 		}
+		applier.health = newHealthChecker(applier.refresh)
+		applier.outlier = newOutlierDetector(endpoint, applier.refresh)
+		applier.outlier.start()
 		if err := applier.apply(ctx); err != nil {
+			applier.Cancel()
 			return nil, err
 		}
 		client := newClient(applier, picker)
 		return client, nil
 	}
+	return factory
 }
 
 type nodeApplier struct {
@@ -102,11 +122,24 @@ type nodeApplier struct {
 	endpoint     *config.Endpoint
 	registry     registry.Discovery
 	picker       selector.Selector
+
+	lock         sync.Mutex
+	backendNodes [][]*node
+	health       *healthChecker
+	outlier      *outlierDetector
+	locality     *localityBalancer
+	localState   atomic.Pointer[localityState]
+}
+
+// backendApplier applies the discovered nodes of a single backend.
+type backendApplier struct {
+	*nodeApplier
+	index   int
+	backend *config.Backend
 }
 
 func (na *nodeApplier) apply(ctx context.Context) error {
-	var nodes []selector.Node
-	for _, backend := range na.endpoint.Backends {
+	for i, backend := range na.endpoint.Backends {
 		target, err := parseTarget(backend.Target)
 		if err != nil {
 			return err
@@ -114,11 +147,10 @@ func (na *nodeApplier) apply(ctx context.Context) error {
 		switch target.Scheme {
 		case "direct":
 			weighted := backend.Weight // weight is only valid for direct scheme
-			node := newNode(na.buildContext, backend.Target, na.endpoint.Protocol, weighted, backend.Metadata, "", "", WithTLS(backend.Tls), WithTLSConfigName(backend.TlsConfigName))
-			nodes = append(nodes, node)
-			na.picker.Apply(nodes)
+			n := newNode(na.buildContext, backend.Target, na.endpoint.Protocol, weighted, backend.Metadata, "", "", WithTLS(backend.Tls), WithTLSConfigName(backend.TlsConfigName), WithHealthCheck(backend.HealthCheck), WithPriority(backend.Priority))
+			na.setBackendNodes(i, []*node{n})
 		case "discovery":
-			existed := AddWatch(ctx, na.registry, target.Endpoint, na)
+			existed := AddWatch(ctx, na.registry, target.Endpoint, &backendApplier{nodeApplier: na, index: i, backend: backend})
 			if existed {
 				log.Infof("watch target %+v already existed", target)
 			}
@@ -129,6 +161,50 @@ func (na *nodeApplier) apply(ctx context.Context) error {
 	return nil
 }
 
+func (na *nodeApplier) allNodes() []*node {
+	var nodes []*node
+	for _, backendNodes := range na.backendNodes {
+		nodes = append(nodes, backendNodes...)
+	}
+	return nodes
+}
+
+func (na *nodeApplier) setBackendNodes(index int, nodes []*node) {
+	func() {
+		na.lock.Lock()
+		defer na.lock.Unlock()
+		na.backendNodes[index] = nodes
+		all := na.allNodes()
+		na.health.update(all)
+		na.outlier.update(all)
+	}()
+	na.refresh()
+}
+
+// refresh applies all available nodes to the picker.
+func (na *nodeApplier) refresh() {
+	na.lock.Lock()
+	defer na.lock.Unlock()
+
+	all := na.allNodes()
+	available := make([]selector.Node, 0, len(all))
+	for _, n := range all {
+		if na.health.isHealthy(n.address) && !na.outlier.isEjected(n.address) {
+			available = append(available, n)
+		}
+	}
+	if len(available) == 0 && len(all) > 0 {
+		log.Warnf("No available nodes on endpoint: [%s] %s %s, applying all %d nodes instead", na.endpoint.Protocol, na.endpoint.Method, na.endpoint.Path, len(all))
+		for _, n := range all {
+			available = append(available, n)
+		}
+	}
+	if na.locality != nil {
+		na.localState.Store(na.locality.state(all, available))
+	}
+	na.picker.Apply(available)
+}
+
 var _defaultWeight = int64(10)
 
 func nodeWeight(n *registry.ServiceInstance) *int64 {
@@ -143,25 +219,25 @@ func nodeWeight(n *registry.ServiceInstance) *int64 {
 	return &_defaultWeight
 }
 
-func (na *nodeApplier) Callback(services []*registry.ServiceInstance) error {
-	if atomic.LoadInt64(&na.canceled) == 1 {
+func (ba *backendApplier) Callback(services []*registry.ServiceInstance) error {
+	if atomic.LoadInt64(&ba.canceled) == 1 {
 		return ErrCancelWatch
 	}
 	if len(services) == 0 {
 		return nil
 	}
-	scheme := strings.ToLower(na.endpoint.Protocol.String())
-	nodes := make([]selector.Node, 0, len(services))
+	scheme := strings.ToLower(ba.endpoint.Protocol.String())
+	nodes := make([]*node, 0, len(services))
 	for _, ser := range services {
 		addr, err := parseEndpoint(ser.Endpoints, scheme, false)
 		if err != nil || addr == "" {
 			log.Errorf("failed to parse endpoint: %v/%s: %v", ser.Endpoints, scheme, err)
 			continue
 		}
-		node := newNode(na.buildContext, addr, na.endpoint.Protocol, nodeWeight(ser), ser.Metadata, ser.Version, ser.Name, WithTLS(false))
+		node := newNode(ba.buildContext, addr, ba.endpoint.Protocol, nodeWeight(ser), ser.Metadata, ser.Version, ser.Name, WithTLS(false), WithHealthCheck(ba.backend.HealthCheck), WithPriority(ba.backend.Priority))
 		nodes = append(nodes, node)
 	}
-	na.picker.Apply(nodes)
+	ba.setBackendNodes(ba.index, nodes)
 	return nil
 }
 
@@ -169,8 +245,14 @@ func (na *nodeApplier) Cancel() {
 	log.Infof("Closing node applier for endpoint: %+v", na.endpoint)
 	atomic.StoreInt64(&na.canceled, 1)
 	na.cancel()
+	na.health.close()
+	na.outlier.close()
 }
 
 func (na *nodeApplier) Canceled() bool {
 	return atomic.LoadInt64(&na.canceled) == 1
 }
+
+func (na *nodeApplier) InspectOutlier() (string, map[string]OutlierStatus) {
+	return fmt.Sprintf("%s %s", na.endpoint.Method, na.endpoint.Path), na.outlier.inspect()
+}
@@ -0,0 +1,355 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var (
+	_defaultHealthCheckInterval = 10 * time.Second
+	_defaultHealthCheckTimeout  = time.Second
+	_defaultHealthyThreshold    = 1
+	_defaultUnhealthyThreshold  = 3
+)
+
+// grpc.health.v1.HealthCheckResponse.ServingStatus.SERVING
+const _grpcHealthServing = 1
+
+type healthProber func(ctx context.Context, n *node) error
+
+type healthTarget struct {
+	node               *node
+	prober             healthProber
+	interval           time.Duration
+	timeout            time.Duration
+	healthyThreshold   int
+	unhealthyThreshold int
+
+	healthy   atomic.Bool
+	successes int
+	failures  int
+	cancel    context.CancelFunc
+}
+
+// healthChecker runs active probes against nodes which have health check configured.
+type healthChecker struct {
+	lock     sync.Mutex
+	targets  map[string]*healthTarget
+	onChange func()
+}
+
+func newHealthChecker(onChange func()) *healthChecker {
+	return &healthChecker{
+		targets:  make(map[string]*healthTarget),
+		onChange: onChange,
+	}
+}
+
+func newHealthTarget(n *node, hc *config.HealthCheck) (*healthTarget, error) {
+	prober, err := makeHealthProber(hc)
+	if err != nil {
+		return nil, err
+	}
+	t := &healthTarget{
+		node:               n,
+		prober:             prober,
+		interval:           _defaultHealthCheckInterval,
+		timeout:            _defaultHealthCheckTimeout,
+		healthyThreshold:   _defaultHealthyThreshold,
+		unhealthyThreshold: _defaultUnhealthyThreshold,
+	}
+	if hc.Interval != nil && hc.Interval.AsDuration() > 0 {
+		t.interval = hc.Interval.AsDuration()
+	}
+	if hc.Timeout != nil && hc.Timeout.AsDuration() > 0 {
+		t.timeout = hc.Timeout.AsDuration()
+	}
+	if hc.HealthyThreshold > 0 {
+		t.healthyThreshold = int(hc.HealthyThreshold)
+	}
+	if hc.UnhealthyThreshold > 0 {
+		t.unhealthyThreshold = int(hc.UnhealthyThreshold)
+	}
+	// nodes are considered healthy until proven otherwise
+	t.healthy.Store(true)
+	return t, nil
+}
+
+// update starts probing the newly added nodes and stops probing the removed ones.
+func (h *healthChecker) update(nodes []*node) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	current := make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		// the health check without checker means no active checking
+		if n.healthCheck.GetChecker() == nil {
+			continue
+		}
+		current[n.address] = struct{}{}
+		if _, ok := h.targets[n.address]; ok {
+			continue
+		}
+		target, err := newHealthTarget(n, n.healthCheck)
+		if err != nil {
+			log.Errorf("Failed to create health check on node: %s: %+v", n.address, err)
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		target.cancel = cancel
+		h.targets[n.address] = target
+		go h.run(ctx, target)
+	}
+	for addr, target := range h.targets {
+		if _, ok := current[addr]; ok {
+			continue
+		}
+		target.cancel()
+		delete(h.targets, addr)
+	}
+}
+
+func (h *healthChecker) isHealthy(addr string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	target, ok := h.targets[addr]
+	if !ok {
+		return true
+	}
+	return target.healthy.Load()
+}
+
+func (h *healthChecker) close() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for addr, target := range h.targets {
+		target.cancel()
+		delete(h.targets, addr)
+	}
+}
+
+func (h *healthChecker) run(ctx context.Context, t *healthTarget) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		if h.probe(ctx, t) {
+			h.onChange()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe runs a single check and reports whether the health state of target has changed.
+func (h *healthChecker) probe(ctx context.Context, t *healthTarget) bool {
+	probeCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	err := t.prober(probeCtx, t.node)
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		t.successes = 0
+		t.failures++
+		if t.healthy.Load() && t.failures >= t.unhealthyThreshold {
+			t.healthy.Store(false)
+			log.Warnf("Node %s is marked as unhealthy after %d failed health checks: %+v", t.node.address, t.failures, err)
+			return true
+		}
+		return false
+	}
+	t.failures = 0
+	t.successes++
+	if !t.healthy.Load() && t.successes >= t.healthyThreshold {
+		t.healthy.Store(true)
+		log.Infof("Node %s is marked as healthy after %d succeeded health checks", t.node.address, t.successes)
+		return true
+	}
+	return false
+}
+
+func makeHealthProber(hc *config.HealthCheck) (healthProber, error) {
+	switch checker := hc.Checker.(type) {
+	case *config.HealthCheck_Http:
+		return makeHTTPHealthProber(checker.Http)
+	case *config.HealthCheck_Grpc:
+		return makeGRPCHealthProber(checker.Grpc), nil
+	default:
+		return nil, fmt.Errorf("unknown health checker: %T", checker)
+	}
+}
+
+func parseExpectedStatuses(in string) (int, int, error) {
+	if in == "" {
+		return 200, 399, nil
+	}
+	parts := strings.Split(in, "-")
+	if len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid expected statuses %s", in)
+	}
+	codes := make([]int, 0, len(parts))
+	for _, p := range parts {
+		code, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return 0, 0, err
+		}
+		codes = append(codes, code)
+	}
+	if len(codes) == 1 {
+		return codes[0], codes[0], nil
+	}
+	return codes[0], codes[1], nil
+}
+
+func healthCheckScheme(n *node) string {
+	if n.tls {
+		return "https"
+	}
+	return "http"
+}
+
+func makeHTTPHealthProber(hc *config.HTTPHealthCheck) (healthProber, error) {
+	low, high, err := parseExpectedStatuses(hc.ExpectedStatuses)
+	if err != nil {
+		return nil, err
+	}
+	method := hc.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	path := hc.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return func(ctx context.Context, n *node) error {
+		req, err := http.NewRequestWithContext(ctx, method, healthCheckScheme(n)+"://"+n.address+path, nil)
+		if err != nil {
+			return err
+		}
+		if hc.Host != "" {
+			req.Host = hc.Host
+		}
+		client := n.client
+		if n.protocol == config.Protocol_GRPC && !n.tls {
+			// plain HTTP probes can not be sent over h2c
+			client = _globalClient
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		if resp.StatusCode < low || resp.StatusCode > high {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		return nil
+	}, nil
+}
+
+func encodeGRPCHealthRequest(service string) []byte {
+	var msg []byte
+	if service != "" {
+		msg = protowire.AppendTag(msg, 1, protowire.BytesType)
+		msg = protowire.AppendString(msg, service)
+	}
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+func decodeGRPCHealthResponse(in []byte) (int, error) {
+	if len(in) < 5 {
+		return 0, fmt.Errorf("invalid grpc frame: %d bytes", len(in))
+	}
+	size := binary.BigEndian.Uint32(in[1:5])
+	msg := in[5:]
+	if uint32(len(msg)) < size {
+		return 0, fmt.Errorf("truncated grpc message: %d < %d", len(msg), size)
+	}
+	msg = msg[:size]
+	status := 0
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		msg = msg[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(msg)
+			if n < 0 {
+				return 0, protowire.ParseError(n)
+			}
+			status = int(v)
+			msg = msg[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, msg)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		msg = msg[n:]
+	}
+	return status, nil
+}
+
+func makeGRPCHealthProber(hc *config.GRPCHealthCheck) healthProber {
+	body := encodeGRPCHealthRequest(hc.Service)
+	return func(ctx context.Context, n *node) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, healthCheckScheme(n)+"://"+n.address+"/grpc.health.v1.Health/Check", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/grpc")
+		req.Header.Set("TE", "trailers")
+		client := n.client
+		if n.protocol != config.Protocol_GRPC && !n.tls {
+			client = _globalH2CClient
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		reply, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if err != nil {
+			return err
+		}
+		grpcStatus := resp.Trailer.Get("Grpc-Status")
+		if grpcStatus == "" {
+			grpcStatus = resp.Header.Get("Grpc-Status")
+		}
+		if grpcStatus != "0" {
+			return fmt.Errorf("unexpected grpc status: %q: %s", grpcStatus, resp.Trailer.Get("Grpc-Message"))
+		}
+		status, err := decodeGRPCHealthResponse(reply)
+		if err != nil {
+			return err
+		}
+		if status != _grpcHealthServing {
+			return fmt.Errorf("unexpected serving status: %d", status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestParseExpectedStatuses(t *testing.T) {
+	testCases := []struct {
+		in        string
+		low, high int
+	}{
+		{in: "", low: 200, high: 399},
+		{in: "204", low: 204, high: 204},
+		{in: "200-299", low: 200, high: 299},
+	}
+	for _, testCase := range testCases {
+		low, high, err := parseExpectedStatuses(testCase.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if low != testCase.low || high != testCase.high {
+			t.Errorf("parseExpectedStatuses(%q) = %d-%d, want %d-%d", testCase.in, low, high, testCase.low, testCase.high)
+		}
+	}
+	if _, _, err := parseExpectedStatuses("200-299-399"); err == nil {
+		t.Errorf("expected error on invalid statuses")
+	}
+}
+
+func TestGRPCHealthMessage(t *testing.T) {
+	req := encodeGRPCHealthRequest("helloworld.Greeter")
+	if req[0] != 0 {
+		t.Fatalf("unexpected compressed flag: %d", req[0])
+	}
+	var reply []byte
+	reply = protowire.AppendTag(reply, 1, protowire.VarintType)
+	reply = protowire.AppendVarint(reply, _grpcHealthServing)
+	frame := encodeGRPCHealthRequest("")
+	frame[4] = byte(len(reply))
+	status, err := decodeGRPCHealthResponse(append(frame, reply...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != _grpcHealthServing {
+		t.Errorf("expected serving status, got %d", status)
+	}
+}
+
+func TestHealthChecker(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	hc := &config.HealthCheck{
+		Checker: &config.HealthCheck_Http{
+			Http: &config.HTTPHealthCheck{Path: "/healthz"},
+		},
+		HealthyThreshold:   2,
+		UnhealthyThreshold: 2,
+	}
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	n := newNode(EmptyBuildContext(), addr, config.Protocol_HTTP, nil, nil, "", "", WithHealthCheck(hc))
+	target, err := newHealthTarget(n, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := newHealthChecker(func() {})
+	ctx := context.Background()
+
+	if checker.probe(ctx, target) || !target.healthy.Load() {
+		t.Fatalf("expected node to stay healthy")
+	}
+	healthy.Store(false)
+	if checker.probe(ctx, target) || !target.healthy.Load() {
+		t.Fatalf("expected node to stay healthy before reaching the unhealthy threshold")
+	}
+	if !checker.probe(ctx, target) || target.healthy.Load() {
+		t.Fatalf("expected node to be marked as unhealthy")
+	}
+	healthy.Store(true)
+	if checker.probe(ctx, target) || target.healthy.Load() {
+		t.Fatalf("expected node to stay unhealthy before reaching the healthy threshold")
+	}
+	if !checker.probe(ctx, target) || !target.healthy.Load() {
+		t.Fatalf("expected node to be marked as healthy")
+	}
+}
+
+func TestHealthCheckerWithoutChecker(t *testing.T) {
+	checker := newHealthChecker(func() {})
+	n := newNode(EmptyBuildContext(), "127.0.0.1:8000", config.Protocol_HTTP, nil, nil, "", "", WithHealthCheck(&config.HealthCheck{}))
+	checker.update([]*node{n})
+	if len(checker.targets) != 0 || !checker.isHealthy(n.address) {
+		t.Fatalf("expected no active checking without checker, got %d targets", len(checker.targets))
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+const (
+	_defaultZoneKey           = "zone"
+	_defaultRegionKey         = "region"
+	_defaultMinHealthyPercent = 70
+)
+
+// Locality is the zone and region of the gateway.
+type Locality struct {
+	Zone   string
+	Region string
+}
+
+// WithLocality sets the locality of the gateway for the endpoints with locality enabled.
+func WithLocality(zone, region string) Option {
+	return func(o *options) {
+		o.locality = Locality{Zone: zone, Region: region}
+	}
+}
+
+// localityState is whether the local nodes have enough healthy capacity.
+type localityState struct {
+	zoneHealthy   bool
+	regionHealthy bool
+}
+
+type localityBalancer struct {
+	local             Locality
+	zoneKey           string
+	regionKey         string
+	minHealthyPercent int
+}
+
+func newLocalityBalancer(local Locality, c *config.Locality) *localityBalancer {
+	if c == nil || (local.Zone == "" && local.Region == "") {
+		return nil
+	}
+	lb := &localityBalancer{
+		local:             local,
+		zoneKey:           c.ZoneKey,
+		regionKey:         c.RegionKey,
+		minHealthyPercent: int(c.MinHealthyPercent),
+	}
+	if lb.zoneKey == "" {
+		lb.zoneKey = _defaultZoneKey
+	}
+	if lb.regionKey == "" {
+		lb.regionKey = _defaultRegionKey
+	}
+	if lb.minHealthyPercent == 0 {
+		lb.minHealthyPercent = _defaultMinHealthyPercent
+	}
+	return lb
+}
+
+func (lb *localityBalancer) inZone(n selector.Node) bool {
+	return lb.local.Zone != "" && n.Metadata()[lb.zoneKey] == lb.local.Zone
+}
+
+func (lb *localityBalancer) inRegion(n selector.Node) bool {
+	return lb.local.Region != "" && n.Metadata()[lb.regionKey] == lb.local.Region
+}
+
+// state compares the healthy local nodes with all the local nodes.
+func (lb *localityBalancer) state(all []*node, available []selector.Node) *localityState {
+	var zoneTotal, zoneAvailable, regionTotal, regionAvailable int
+	for _, n := range all {
+		if lb.inZone(n) {
+			zoneTotal++
+		}
+		if lb.inRegion(n) {
+			regionTotal++
+		}
+	}
+	for _, n := range available {
+		if lb.inZone(n) {
+			zoneAvailable++
+		}
+		if lb.inRegion(n) {
+			regionAvailable++
+		}
+	}
+	return &localityState{
+		zoneHealthy:   zoneTotal > 0 && zoneAvailable*100 >= zoneTotal*lb.minHealthyPercent,
+		regionHealthy: regionTotal > 0 && regionAvailable*100 >= regionTotal*lb.minHealthyPercent,
+	}
+}
+
+// filter returns the node filter preferring the nodes in the same zone, then in the same region.
+func (lb *localityBalancer) filter(state func() *localityState) selector.NodeFilter {
+	return func(_ context.Context, nodes []selector.Node) []selector.Node {
+		s := state()
+		if s == nil {
+			return nodes
+		}
+		if s.zoneHealthy {
+			if local := filterNodes(nodes, lb.inZone); len(local) > 0 {
+				return local
+			}
+		}
+		if s.regionHealthy {
+			if local := filterNodes(nodes, lb.inRegion); len(local) > 0 {
+				return local
+			}
+		}
+		return nodes
+	}
+}
+
+func filterNodes(nodes []selector.Node, keep func(selector.Node) bool) []selector.Node {
+	filtered := make([]selector.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if keep(n) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
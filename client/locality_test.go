@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+func TestLocality(t *testing.T) {
+	lb := newLocalityBalancer(Locality{Zone: "a1", Region: "a"}, &config.Locality{MinHealthyPercent: 50})
+	newZoneNode := func(addr, zone, region string) *node {
+		return newNode(EmptyBuildContext(), addr, config.Protocol_HTTP, nil, map[string]string{"zone": zone, "region": region}, "", "")
+	}
+	all := []*node{
+		newZoneNode("zone-1", "a1", "a"),
+		newZoneNode("zone-2", "a1", "a"),
+		newZoneNode("region-1", "a2", "a"),
+		newZoneNode("region-2", "a2", "a"),
+		newZoneNode("remote-1", "b1", "b"),
+	}
+	route := func(available ...*node) []string {
+		nodes := make([]selector.Node, 0, len(available))
+		for _, n := range available {
+			nodes = append(nodes, n)
+		}
+		state := lb.state(all, nodes)
+		var addrs []string
+		for _, n := range lb.filter(func() *localityState { return state })(context.Background(), nodes) {
+			addrs = append(addrs, n.Address())
+		}
+		return addrs
+	}
+	if addrs := route(all...); len(addrs) != 2 || addrs[0] != "zone-1" || addrs[1] != "zone-2" {
+		t.Fatalf("expected the nodes in the same zone, got %v", addrs)
+	}
+	if addrs := route(all[1:]...); len(addrs) != 1 || addrs[0] != "zone-2" {
+		t.Fatalf("expected the healthy node in the same zone, got %v", addrs)
+	}
+	// the zone has no nodes available
+	if addrs := route(all[2:]...); len(addrs) != 2 || addrs[0] != "region-1" {
+		t.Fatalf("expected to spill over to the region, got %v", addrs)
+	}
+	if addrs := route(all[4:]...); len(addrs) != 1 || addrs[0] != "remote-1" {
+		t.Fatalf("expected to spill over to the other regions, got %v", addrs)
+	}
+	lb.minHealthyPercent = 100
+	if addrs := route(all[1:]...); len(addrs) != 4 {
+		t.Fatalf("expected to spill over to all the nodes below the healthy percent, got %v", addrs)
+	}
+	if newLocalityBalancer(Locality{}, &config.Locality{}) != nil {
+		t.Fatal("expected locality to be disabled without the gateway locality")
+	}
+}
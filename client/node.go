@@ -155,6 +155,8 @@ func (s *HTTPSClientStore) GetClient(name string) *http.Client {
 type NodeOptions struct {
 	TLS           bool
 	TLSConfigName string
+	HealthCheck   *config.HealthCheck
+	Priority      string
 }
 type NewNodeOption func(*NodeOptions)
 
@@ -170,6 +172,18 @@ func WithTLSConfigName(in string) NewNodeOption {
 	}
 }
 
+func WithHealthCheck(in *config.HealthCheck) NewNodeOption {
+	return func(o *NodeOptions) {
+		o.HealthCheck = in
+	}
+}
+
+func WithPriority(in string) NewNodeOption {
+	return func(o *NodeOptions) {
+		o.Priority = in
+	}
+}
+
 func newNode(ctx *BuildContext, addr string, protocol config.Protocol, weight *int64, md map[string]string, version string, name string, opts ...NewNodeOption) *node {
 	node := &node{
 		protocol: protocol,
@@ -187,6 +201,8 @@ func newNode(ctx *BuildContext, addr string, protocol config.Protocol, weight *i
 	for _, o := range opts {
 		o(opt)
 	}
+	node.healthCheck = opt.HealthCheck
+	node.priority = opt.Priority
 	if opt.TLS {
 		node.tls = true
 		node.client = _globalHTTPSClient
@@ -204,9 +220,11 @@ type node struct {
 	version  string
 	metadata map[string]string
 
-	client   *http.Client
-	protocol config.Protocol
-	tls      bool
+	client      *http.Client
+	protocol    config.Protocol
+	tls         bool
+	healthCheck *config.HealthCheck
+	priority    string
 }
 
 func (n *node) Scheme() string {
@@ -0,0 +1,301 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_defaultOutlierInterval           = 10 * time.Second
+	_defaultOutlierBaseEjectionTime   = 30 * time.Second
+	_defaultOutlierMaxEjectionTime    = 300 * time.Second
+	_defaultOutlierMaxEjectionPercent = 10
+)
+
+const (
+	ejectReason5xx                = "5xx"
+	ejectReasonGatewayFailure     = "gateway_failure"
+	ejectReasonLocalOriginFailure = "local_origin_failure"
+)
+
+var (
+	_metricOutlierEjectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "outlier_ejections_total",
+		Help:      "The total number of outlier ejections",
+	}, []string{"protocol", "method", "path", "service", "basePath", "reason"})
+	_metricOutlierEjectedNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "outlier_ejected_nodes",
+		Help:      "The number of currently ejected nodes",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricOutlierEjectionsTotal)
+	prometheus.MustRegister(_metricOutlierEjectedNodes)
+}
+
+// OutlierStatus is the passive health state of a node.
+type OutlierStatus struct {
+	Consecutive5xx                int       `json:"consecutive5xx"`
+	ConsecutiveGatewayFailure     int       `json:"consecutiveGatewayFailure"`
+	ConsecutiveLocalOriginFailure int       `json:"consecutiveLocalOriginFailure"`
+	Ejections                     int       `json:"ejections"`
+	Ejected                       bool      `json:"ejected"`
+	EjectedUntil                  time.Time `json:"ejectedUntil,omitempty"`
+}
+
+// outlierDetector ejects the nodes which continuously fail for a period of time.
+type outlierDetector struct {
+	lock       sync.Mutex
+	enabled    bool
+	config     *config.OutlierDetection
+	labels     middleware.MetricsLabels
+	statuses   map[string]*OutlierStatus
+	onChange   func()
+	cancel     context.CancelFunc
+	interval   time.Duration
+	baseTime   time.Duration
+	maxTime    time.Duration
+	maxPercent int
+}
+
+func newOutlierDetector(endpoint *config.Endpoint, onChange func()) *outlierDetector {
+	d := &outlierDetector{
+		config:     endpoint.OutlierDetection,
+		labels:     middleware.NewMetricsLabels(endpoint),
+		statuses:   make(map[string]*OutlierStatus),
+		onChange:   onChange,
+		interval:   _defaultOutlierInterval,
+		baseTime:   _defaultOutlierBaseEjectionTime,
+		maxTime:    _defaultOutlierMaxEjectionTime,
+		maxPercent: _defaultOutlierMaxEjectionPercent,
+	}
+	c := endpoint.OutlierDetection
+	if c == nil {
+		return d
+	}
+	d.enabled = c.Consecutive_5Xx > 0 || c.ConsecutiveGatewayFailure > 0 || c.ConsecutiveLocalOriginFailure > 0
+	if c.Interval != nil && c.Interval.AsDuration() > 0 {
+		d.interval = c.Interval.AsDuration()
+	}
+	if c.BaseEjectionTime != nil && c.BaseEjectionTime.AsDuration() > 0 {
+		d.baseTime = c.BaseEjectionTime.AsDuration()
+	}
+	if c.MaxEjectionTime != nil && c.MaxEjectionTime.AsDuration() > 0 {
+		d.maxTime = c.MaxEjectionTime.AsDuration()
+	}
+	if c.MaxEjectionPercent > 0 {
+		d.maxPercent = int(c.MaxEjectionPercent)
+	}
+	return d
+}
+
+func (d *outlierDetector) start() {
+	if !d.enabled {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if d.sweep(time.Now()) {
+				d.onChange()
+			}
+		}
+	}()
+}
+
+func (d *outlierDetector) close() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	if d.enabled {
+		_metricOutlierEjectedNodes.DeleteLabelValues(d.labels.Protocol(), d.labels.Method(), d.labels.Path(), d.labels.Service(), d.labels.BasePath())
+	}
+}
+
+// update keeps the status of the current nodes only.
+func (d *outlierDetector) update(nodes []*node) {
+	if !d.enabled {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	current := make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		current[n.address] = struct{}{}
+		if _, ok := d.statuses[n.address]; !ok {
+			d.statuses[n.address] = &OutlierStatus{}
+		}
+	}
+	for addr := range d.statuses {
+		if _, ok := current[addr]; !ok {
+			delete(d.statuses, addr)
+		}
+	}
+	d.updateEjectedGauge()
+}
+
+func (d *outlierDetector) isEjected(addr string) bool {
+	if !d.enabled {
+		return false
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	status, ok := d.statuses[addr]
+	return ok && status.Ejected
+}
+
+func isLocalOriginFailure(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled)
+}
+
+func isGatewayFailure(statusCode int) bool {
+	return statusCode == http.StatusBadGateway ||
+		statusCode == http.StatusServiceUnavailable ||
+		statusCode == http.StatusGatewayTimeout
+}
+
+// report records the result of a request to the node.
+func (d *outlierDetector) report(addr string, statusCode int, err error) {
+	if !d.enabled {
+		return
+	}
+	if err != nil && !isLocalOriginFailure(err) {
+		return
+	}
+	ejected := func() bool {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+
+		status, ok := d.statuses[addr]
+		if !ok || status.Ejected {
+			return false
+		}
+		localOrigin := err != nil
+		if localOrigin {
+			status.ConsecutiveLocalOriginFailure++
+		} else {
+			status.ConsecutiveLocalOriginFailure = 0
+		}
+		if localOrigin || statusCode >= 500 {
+			status.Consecutive5xx++
+		} else {
+			status.Consecutive5xx = 0
+		}
+		if localOrigin || isGatewayFailure(statusCode) {
+			status.ConsecutiveGatewayFailure++
+		} else {
+			status.ConsecutiveGatewayFailure = 0
+		}
+
+		c := d.config
+		switch {
+		case c.ConsecutiveLocalOriginFailure > 0 && status.ConsecutiveLocalOriginFailure >= int(c.ConsecutiveLocalOriginFailure):
+			return d.eject(addr, status, ejectReasonLocalOriginFailure)
+		case c.ConsecutiveGatewayFailure > 0 && status.ConsecutiveGatewayFailure >= int(c.ConsecutiveGatewayFailure):
+			return d.eject(addr, status, ejectReasonGatewayFailure)
+		case c.Consecutive_5Xx > 0 && status.Consecutive5xx >= int(c.Consecutive_5Xx):
+			return d.eject(addr, status, ejectReason5xx)
+		}
+		return false
+	}()
+	if ejected {
+		d.onChange()
+	}
+}
+
+func (d *outlierDetector) ejectedCount() int {
+	ejected := 0
+	for _, status := range d.statuses {
+		if status.Ejected {
+			ejected++
+		}
+	}
+	return ejected
+}
+
+func (d *outlierDetector) eject(addr string, status *OutlierStatus, reason string) bool {
+	// at least one node could be ejected regardless of the max ejection percent
+	if ejected := d.ejectedCount(); ejected > 0 && (ejected+1)*100 > len(d.statuses)*d.maxPercent {
+		log.Warnf("Skip ejecting outlier node %s on endpoint: %s %s, max ejection percent %d%% reached", addr, d.labels.Method(), d.labels.Path(), d.maxPercent)
+		return false
+	}
+	status.Ejections++
+	ejectionTime := d.baseTime << (status.Ejections - 1)
+	if ejectionTime > d.maxTime || ejectionTime <= 0 {
+		ejectionTime = d.maxTime
+	}
+	status.Ejected = true
+	status.EjectedUntil = time.Now().Add(ejectionTime)
+	status.Consecutive5xx = 0
+	status.ConsecutiveGatewayFailure = 0
+	status.ConsecutiveLocalOriginFailure = 0
+	log.Warnf("Ejecting outlier node %s on endpoint: %s %s for %s, reason: %s", addr, d.labels.Method(), d.labels.Path(), ejectionTime, reason)
+	_metricOutlierEjectionsTotal.WithLabelValues(d.labels.Protocol(), d.labels.Method(), d.labels.Path(), d.labels.Service(), d.labels.BasePath(), reason).Inc()
+	d.updateEjectedGauge()
+	return true
+}
+
+// sweep unejects the expired nodes and decays the ejection multiplier of healthy nodes.
+func (d *outlierDetector) sweep(now time.Time) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	changed := false
+	for addr, status := range d.statuses {
+		if !status.Ejected {
+			if status.Ejections > 0 {
+				status.Ejections--
+			}
+			continue
+		}
+		if now.Before(status.EjectedUntil) {
+			continue
+		}
+		status.Ejected = false
+		status.EjectedUntil = time.Time{}
+		changed = true
+		log.Infof("Unejecting outlier node %s on endpoint: %s %s", addr, d.labels.Method(), d.labels.Path())
+	}
+	if changed {
+		d.updateEjectedGauge()
+	}
+	return changed
+}
+
+func (d *outlierDetector) updateEjectedGauge() {
+	_metricOutlierEjectedNodes.WithLabelValues(d.labels.Protocol(), d.labels.Method(), d.labels.Path(), d.labels.Service(), d.labels.BasePath()).Set(float64(d.ejectedCount()))
+}
+
+func (d *outlierDetector) inspect() map[string]OutlierStatus {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	out := make(map[string]OutlierStatus, len(d.statuses))
+	for addr, status := range d.statuses {
+		out[addr] = *status
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestOutlierDetector(t *testing.T) {
+	endpoint := &config.Endpoint{
+		Path: "/outlier",
+		OutlierDetection: &config.OutlierDetection{
+			Consecutive_5Xx:               2,
+			ConsecutiveLocalOriginFailure: 1,
+			BaseEjectionTime:              durationpb.New(time.Second),
+			MaxEjectionTime:               durationpb.New(3 * time.Second),
+			MaxEjectionPercent:            50,
+		},
+	}
+	changed := 0
+	d := newOutlierDetector(endpoint, func() { changed++ })
+	defer d.close()
+	d.update([]*node{{address: "127.0.0.1:8000"}, {address: "127.0.0.1:8001"}, {address: "127.0.0.1:8002"}})
+
+	d.report("127.0.0.1:8000", http.StatusInternalServerError, nil)
+	d.report("127.0.0.1:8000", http.StatusOK, nil)
+	d.report("127.0.0.1:8000", http.StatusInternalServerError, nil)
+	if d.isEjected("127.0.0.1:8000") {
+		t.Fatalf("expected node not to be ejected after non-consecutive failures")
+	}
+	d.report("127.0.0.1:8000", http.StatusInternalServerError, nil)
+	if !d.isEjected("127.0.0.1:8000") || changed != 1 {
+		t.Fatalf("expected node to be ejected after consecutive 5xx")
+	}
+
+	// max ejection percent reached
+	d.report("127.0.0.1:8001", 0, errors.New("connection refused"))
+	if d.isEjected("127.0.0.1:8001") {
+		t.Fatalf("expected node not to be ejected over the max ejection percent")
+	}
+
+	status := d.inspect()["127.0.0.1:8000"]
+	if ejectionTime := time.Until(status.EjectedUntil); ejectionTime > time.Second {
+		t.Fatalf("unexpected ejection time: %s", ejectionTime)
+	}
+	if !d.sweep(status.EjectedUntil) || d.isEjected("127.0.0.1:8000") {
+		t.Fatalf("expected node to be unejected after the ejection time")
+	}
+
+	d.report("127.0.0.1:8001", 0, errors.New("connection refused"))
+	if !d.isEjected("127.0.0.1:8001") {
+		t.Fatalf("expected node to be ejected after local origin failure")
+	}
+}
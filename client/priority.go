@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+// unwrapNode returns the backend node of the selector node.
+func unwrapNode(n selector.Node) (*node, bool) {
+	if wn, ok := n.(selector.WeightedNode); ok {
+		n = wn.Raw()
+	}
+	out, ok := n.(*node)
+	return out, ok
+}
+
+// NewPriorityFilter returns a node filter which keeps only the nodes in the first
+// available priority group, nodes without priority belong to the first group.
+// Since the retry filter excludes the tried nodes in advance, the next priority group
+// is selected only after all nodes in the current group are exhausted or unhealthy.
+func NewPriorityFilter(priorities []string) selector.NodeFilter {
+	index := make(map[string]int, len(priorities))
+	for i, p := range priorities {
+		index[p] = i
+	}
+	return func(_ context.Context, nodes []selector.Node) []selector.Node {
+		if len(nodes) == 0 || len(priorities) <= 1 {
+			return nodes
+		}
+		best := len(priorities)
+		levels := make([]int, len(nodes))
+		for i, n := range nodes {
+			levels[i] = 0
+			if bn, ok := unwrapNode(n); ok {
+				levels[i] = index[bn.priority]
+			}
+			if levels[i] < best {
+				best = levels[i]
+			}
+		}
+		selected := make([]selector.Node, 0, len(nodes))
+		for i, n := range nodes {
+			if levels[i] == best {
+				selected = append(selected, n)
+			}
+		}
+		return selected
+	}
+}
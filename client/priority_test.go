@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+func TestPriorityFilter(t *testing.T) {
+	newPriorityNode := func(addr, priority string) selector.Node {
+		return newNode(EmptyBuildContext(), addr, config.Protocol_HTTP, nil, nil, "", "", WithPriority(priority))
+	}
+	filter := NewPriorityFilter([]string{"primary", "secondary"})
+	primary := newPriorityNode("127.0.0.1:8000", "primary")
+	unknown := newPriorityNode("127.0.0.1:8001", "")
+	secondary := newPriorityNode("127.0.0.1:8002", "secondary")
+
+	testCases := []struct {
+		nodes    []selector.Node
+		expected []string
+	}{
+		{
+			nodes:    []selector.Node{primary, unknown, secondary},
+			expected: []string{"127.0.0.1:8000", "127.0.0.1:8001"},
+		},
+		{
+			nodes:    []selector.Node{secondary},
+			expected: []string{"127.0.0.1:8002"},
+		},
+		{
+			nodes:    []selector.Node{},
+			expected: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		selected := filter(context.Background(), testCase.nodes)
+		if len(selected) != len(testCase.expected) {
+			t.Fatalf("expected %d nodes, got %d", len(testCase.expected), len(selected))
+		}
+		for i, n := range selected {
+			if n.Address() != testCase.expected[i] {
+				t.Errorf("expected %s, got %s", testCase.expected[i], n.Address())
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"hash/crc32"
 	"net/http"
+	"net/url"
 	"os"
 	"sort"
 	"strconv"
@@ -278,8 +279,9 @@ func (s *serviceWatcher) DebugHandler() http.Handler {
 	debugMux.HandleFunc("/debug/watcher/nodes", func(w http.ResponseWriter, r *http.Request) {
 		service := r.URL.Query().Get("service")
 		nodes, _ := s.getSelectedCache(service)
+		appliers, _ := s.getAppliers(service)
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(nodes)
+		json.NewEncoder(w).Encode(inspectNodes(nodes, appliers))
 	})
 	debugMux.HandleFunc("/debug/watcher/appliers", func(w http.ResponseWriter, r *http.Request) {
 		service := r.URL.Query().Get("service")
@@ -290,6 +292,48 @@ func (s *serviceWatcher) DebugHandler() http.Handler {
 	return debugMux
 }
 
+type outlierInspector interface {
+	InspectOutlier() (string, map[string]OutlierStatus)
+}
+
+type inspectOutlier struct {
+	Endpoint string `json:"endpoint"`
+	OutlierStatus
+}
+
+type inspectNode struct {
+	*registry.ServiceInstance
+	OutlierDetection []*inspectOutlier `json:"outlierDetection,omitempty"`
+}
+
+func inspectNodes(instances []*registry.ServiceInstance, appliers map[string]Applier) []*inspectNode {
+	var inspectors []outlierInspector
+	for _, applier := range appliers {
+		if inspector, ok := applier.(outlierInspector); ok {
+			inspectors = append(inspectors, inspector)
+		}
+	}
+	out := make([]*inspectNode, 0, len(instances))
+	for _, instance := range instances {
+		node := &inspectNode{ServiceInstance: instance}
+		for _, inspector := range inspectors {
+			endpoint, statuses := inspector.InspectOutlier()
+			for _, e := range instance.Endpoints {
+				u, err := url.Parse(e)
+				if err != nil {
+					continue
+				}
+				if status, ok := statuses[u.Host]; ok {
+					node.OutlierDetection = append(node.OutlierDetection, &inspectOutlier{Endpoint: endpoint, OutlierStatus: status})
+					break
+				}
+			}
+		}
+		out = append(out, node)
+	}
+	return out
+}
+
 func AddWatch(ctx context.Context, registry registry.Discovery, endpoint string, applier Applier) bool {
 	return globalServiceWatcher.Add(ctx, registry, endpoint, applier)
 }
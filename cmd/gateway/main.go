@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -20,11 +23,21 @@ import (
 	_ "net/http/pprof"
 
 	_ "github.com/go-kratos/gateway/discovery/consul"
+	_ "github.com/go-kratos/gateway/middleware/affinity"
 	_ "github.com/go-kratos/gateway/middleware/bbr"
+	_ "github.com/go-kratos/gateway/middleware/cache"
+	_ "github.com/go-kratos/gateway/middleware/canary"
 	"github.com/go-kratos/gateway/middleware/circuitbreaker"
 	_ "github.com/go-kratos/gateway/middleware/cors"
+	"github.com/go-kratos/gateway/middleware/extauthz"
+	_ "github.com/go-kratos/gateway/middleware/fault"
+	_ "github.com/go-kratos/gateway/middleware/jwt"
 	_ "github.com/go-kratos/gateway/middleware/logging"
+	"github.com/go-kratos/gateway/middleware/mirror"
+	_ "github.com/go-kratos/gateway/middleware/mtls"
+	"github.com/go-kratos/gateway/middleware/ratelimit"
 	_ "github.com/go-kratos/gateway/middleware/rewrite"
+	_ "github.com/go-kratos/gateway/middleware/singleflight"
 	_ "github.com/go-kratos/gateway/middleware/tracing"
 	_ "github.com/go-kratos/gateway/middleware/transcoder"
 	_ "go.uber.org/automaxprocs"
@@ -41,9 +54,16 @@ var (
 	ctrlService       string
 	discoveryDSN      string
 	proxyAddrs        = newSliceVar(":8080")
+	proxyTLSAddrs     = newSliceVar()
 	proxyConfig       string
 	priorityConfigDir string
 	withDebug         bool
+	zone              string
+	region            string
+	peerAddr          string
+	peerTLSCert       string
+	peerTLSKey        string
+	peerTLSCA         string
 )
 
 type sliceVar struct {
@@ -71,11 +91,18 @@ func init() {
 
 	flag.BoolVar(&withDebug, "debug", false, "enable debug handlers")
 	flag.Var(&proxyAddrs, "addr", "proxy address, eg: -addr 0.0.0.0:8080")
+	flag.Var(&proxyTLSAddrs, "addr.tls", "proxy address with tls termination, eg: -addr.tls 0.0.0.0:8443")
 	flag.StringVar(&proxyConfig, "conf", "config.yaml", "config path, eg: -conf config.yaml")
 	flag.StringVar(&priorityConfigDir, "conf.priority", "", "priority config directory, eg: -conf.priority ./canary")
 	flag.StringVar(&ctrlName, "ctrl.name", os.Getenv("ADVERTISE_NAME"), "control gateway name, eg: gateway")
 	flag.StringVar(&ctrlService, "ctrl.service", "", "control service host, eg: http://127.0.0.1:8000")
 	flag.StringVar(&discoveryDSN, "discovery.dsn", "", "discovery dsn, eg: consul://127.0.0.1:7070?token=secret&datacenter=prod")
+	flag.StringVar(&zone, "zone", os.Getenv("ADVERTISE_ZONE"), "zone of the gateway for locality aware load balancing, eg: us-east-1a")
+	flag.StringVar(&region, "region", os.Getenv("ADVERTISE_REGION"), "region of the gateway for locality aware load balancing, eg: us-east-1")
+	flag.StringVar(&peerAddr, "ratelimit.peer.addr", "", "rate limit peer store address to receive the counters from peers, eg: -ratelimit.peer.addr 0.0.0.0:7946")
+	flag.StringVar(&peerTLSCert, "ratelimit.peer.tls.cert", "", "rate limit peer server certificate file, the counters are exchanged over tls if it's set")
+	flag.StringVar(&peerTLSKey, "ratelimit.peer.tls.key", "", "rate limit peer server private key file")
+	flag.StringVar(&peerTLSCA, "ratelimit.peer.tls.ca", "", "rate limit peer CA file to verify the peers mutually, the peer stores may omit the secret if it's set")
 }
 
 func makeDiscovery() registry.Discovery {
@@ -89,10 +116,48 @@ func makeDiscovery() registry.Discovery {
 	return d
 }
 
+// servePeers starts the rate limit peer server before the middlewares are created.
+func servePeers() {
+	if peerAddr == "" {
+		return
+	}
+	var tlsConfig *tls.Config
+	if peerTLSCert != "" || peerTLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(peerTLSCert, peerTLSKey)
+		if err != nil {
+			log.Fatalf("failed to load rate limit peer certificate: %v", err)
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+		if peerTLSCA != "" {
+			ca, err := os.ReadFile(peerTLSCA)
+			if err != nil {
+				log.Fatalf("failed to read rate limit peer CA: %v", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+				log.Fatalf("failed to parse rate limit peer CA: %s", peerTLSCA)
+			}
+			// the peers present the same certificate as the client
+			tlsConfig.ClientCAs = tlsConfig.RootCAs
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	lis, err := net.Listen("tcp", peerAddr)
+	if err != nil {
+		log.Fatalf("failed to listen rate limit peer address: %v", err)
+	}
+	if _, err := ratelimit.ServePeers(lis, tlsConfig); err != nil {
+		log.Fatalf("failed to serve rate limit peers: %v", err)
+	}
+}
+
 func main() {
 	flag.Parse()
 
-	clientFactory := client.NewFactory(makeDiscovery())
+	serviceDiscovery := makeDiscovery()
+	clientFactory := client.NewFactory(serviceDiscovery, client.WithLocality(zone, region))
+	ratelimit.SetDiscovery(serviceDiscovery, ctrlName)
+	servePeers()
 	p, err := proxy.New(clientFactory, middleware.Create)
 	if err != nil {
 		log.Fatalf("failed to new proxy: %v", err)
@@ -122,8 +187,14 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	certStore := server.NewCertificateStore()
+	if err := certStore.Update(bc); err != nil {
+		log.Fatalf("failed to load server certificates: %v", err)
+	}
 	buildContext := client.NewBuildContext(bc)
 	circuitbreaker.Init(buildContext, clientFactory)
+	extauthz.Init(buildContext, clientFactory)
+	mirror.Init(buildContext, clientFactory)
 	if err := p.Update(buildContext, bc); err != nil {
 		log.Fatalf("failed to update service config: %v", err)
 	}
@@ -133,8 +204,14 @@ func main() {
 			log.Errorf("failed to load config: %v", err)
 			return err
 		}
+		// the previous certificates are kept on failure, the routing is still applied
+		if err := certStore.Update(bc); err != nil {
+			log.Errorf("failed to update server certificates: %v", err)
+		}
 		buildContext := client.NewBuildContext(bc)
 		circuitbreaker.SetBuildContext(buildContext)
+		extauthz.SetBuildContext(buildContext)
+		mirror.SetBuildContext(buildContext)
 		if err := p.Update(buildContext, bc); err != nil {
 			log.Errorf("failed to update service config: %v", err)
 			return err
@@ -153,10 +230,13 @@ func main() {
 		}
 		serverHandler = debug.MashupWithDebugHandler(p)
 	}
-	servers := make([]transport.Server, 0, len(proxyAddrs.Get()))
+	servers := make([]transport.Server, 0, len(proxyAddrs.Get())+len(proxyTLSAddrs.Get()))
 	for _, addr := range proxyAddrs.Get() {
 		servers = append(servers, server.NewProxy(serverHandler, addr))
 	}
+	for _, addr := range proxyTLSAddrs.Get() {
+		servers = append(servers, server.NewProxy(serverHandler, addr, server.WithTLS(certStore)))
+	}
 	app := kratos.New(
 		kratos.Name(bc.Name),
 		kratos.Context(ctx),
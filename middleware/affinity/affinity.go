@@ -0,0 +1,194 @@
+package affinity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/affinity/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const _defaultCookieName = "GATEWAY_AFFINITY"
+
+const (
+	resultHit     = "hit"
+	resultMiss    = "miss"
+	resultInvalid = "invalid"
+)
+
+var _metricAffinityTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_affinity_total",
+	Help:      "The total number of requests with the affinity cookie by whether the node is available",
+}, []string{"protocol", "method", "path", "service", "basePath", "result"})
+
+func init() {
+	prometheus.MustRegister(_metricAffinityTotal)
+	middleware.Register("affinity", Middleware)
+}
+
+type signer struct {
+	secret []byte
+}
+
+// mac binds the node address to the endpoint, so the cookie is not accepted by the other endpoints.
+func (s *signer) mac(endpoint, addr string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(endpoint))
+	h.Write([]byte{0})
+	h.Write([]byte(addr))
+	return h.Sum(nil)
+}
+
+// sign encodes the node address with its signature.
+func (s *signer) sign(endpoint, addr string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(addr)) + "." + base64.RawURLEncoding.EncodeToString(s.mac(endpoint, addr))
+}
+
+// verify returns the node address of the signed value.
+func (s *signer) verify(endpoint, value string) (string, bool) {
+	encodedAddr, encodedMAC, ok := strings.Cut(value, ".")
+	if !ok {
+		return "", false
+	}
+	addr, err := base64.RawURLEncoding.DecodeString(encodedAddr)
+	if err != nil {
+		return "", false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, s.mac(endpoint, string(addr))) {
+		return "", false
+	}
+	return string(addr), true
+}
+
+func parseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return http.SameSiteDefaultMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	default:
+		return 0, fmt.Errorf("invalid same_site of the affinity cookie: %s", s)
+	}
+}
+
+// endpointKey identifies the endpoint of the request.
+func endpointKey(req *http.Request) string {
+	e, ok := middleware.EndpointFromContext(req.Context())
+	if !ok {
+		return ""
+	}
+	return e.Host + " " + e.Method + " " + e.Path
+}
+
+// defaultCookieName is suffixed by the endpoint hash, so the endpoints don't overwrite the cookies of each other.
+func defaultCookieName(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return _defaultCookieName + "_" + hex.EncodeToString(sum[:4])
+}
+
+func affinityIncr(req *http.Request, result string) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricAffinityTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), result).Inc()
+	}
+}
+
+// Middleware prefers the node of the signed affinity cookie, and issues the cookie of the chosen node.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Affinity{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.Secret == "" {
+		return nil, errors.New("affinity secret is required")
+	}
+	sameSite, err := parseSameSite(options.SameSite)
+	if err != nil {
+		return nil, err
+	}
+	path := options.Path
+	if path == "" {
+		path = "/"
+	}
+	var maxAge int
+	if options.MaxAge != nil {
+		maxAge = int(options.MaxAge.AsDuration().Seconds())
+	}
+	s := &signer{secret: []byte(options.Secret)}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint := endpointKey(req)
+			cookieName := options.CookieName
+			if cookieName == "" {
+				cookieName = defaultCookieName(endpoint)
+			}
+			var preferred string
+			if cookie, err := req.Cookie(cookieName); err == nil {
+				addr, ok := s.verify(endpoint, cookie.Value)
+				if !ok {
+					affinityIncr(req, resultInvalid)
+				}
+				preferred = addr
+			}
+			// the filter added by the first attempt is kept in the request options,
+			// the hedged attempts are sent to the other nodes
+			if preferred != "" && !middleware.IsRetryAttempt(req.Context()) {
+				middleware.WithSelectorFitler(req.Context(), func(ctx context.Context, nodes []selector.Node) []selector.Node {
+					for _, n := range nodes {
+						if n.Address() == preferred {
+							affinityIncr(req, resultHit)
+							return []selector.Node{n}
+						}
+					}
+					// the node is gone or excluded by the retries
+					affinityIncr(req, resultMiss)
+					return nodes
+				})
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			reqOpts, ok := middleware.FromRequestContext(req.Context())
+			if !ok || reqOpts.CurrentNode == nil || reqOpts.CurrentNode.Address() == preferred {
+				return resp, nil
+			}
+			cookie := &http.Cookie{
+				Name:     cookieName,
+				Value:    s.sign(endpoint, reqOpts.CurrentNode.Address()),
+				Path:     path,
+				Domain:   options.Domain,
+				MaxAge:   maxAge,
+				Secure:   options.Secure,
+				HttpOnly: options.HttpOnly,
+				SameSite: sameSite,
+			}
+			if resp.Header == nil {
+				resp.Header = make(http.Header)
+			}
+			resp.Header.Add("Set-Cookie", cookie.String())
+			return resp, nil
+		})
+	}, nil
+}
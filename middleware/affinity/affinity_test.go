@@ -0,0 +1,96 @@
+package affinity
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/affinity/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestAffinity(t *testing.T) {
+	v, err := anypb.New(&v1.Affinity{Secret: "secret", HttpOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := []selector.Node{
+		selector.NewNode("http", "10.0.0.1:8000", &registry.ServiceInstance{}),
+		selector.NewNode("http", "10.0.0.2:8000", &registry.ServiceInstance{}),
+		selector.NewNode("http", "10.0.0.3:8000", &registry.ServiceInstance{}),
+	}
+	// the request is sent to the last candidate
+	route := func(nodes []selector.Node, cookie *http.Cookie) (string, *http.Cookie) {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		reqOpts := middleware.NewRequestOptions(&config.Endpoint{Path: "/foo"})
+		req = req.WithContext(middleware.NewRequestContext(req.Context(), reqOpts))
+		next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			candidates := append([]selector.Node(nil), nodes...)
+			filters, _ := middleware.SelectorFiltersFromContext(req.Context())
+			for _, f := range filters {
+				candidates = f(context.Background(), candidates)
+			}
+			reqOpts.CurrentNode = candidates[len(candidates)-1]
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+		})
+		resp, err := m(next).RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies := resp.Cookies()
+		if len(cookies) == 0 {
+			return reqOpts.CurrentNode.Address(), nil
+		}
+		return reqOpts.CurrentNode.Address(), cookies[0]
+	}
+
+	addr, cookie := route(nodes, nil)
+	if cookie == nil || cookie.Name != defaultCookieName("  /foo") || !cookie.HttpOnly {
+		t.Fatalf("expected the affinity cookie to be issued, got %v", cookie)
+	}
+	// prefer the node of the cookie
+	if got, reissued := route(nodes[1:], &http.Cookie{Name: cookie.Name, Value: (&signer{secret: []byte("secret")}).sign("  /foo", "10.0.0.2:8000")}); got != "10.0.0.2:8000" || reissued != nil {
+		t.Fatalf("expected the node of the cookie without reissue, got %s %v", got, reissued)
+	}
+	if got, _ := route(nodes, cookie); got != addr {
+		t.Fatalf("expected the sticky node %s, got %s", addr, got)
+	}
+	// the node is gone
+	got, reissued := route(nodes[:2], cookie)
+	if got == addr || reissued == nil {
+		t.Fatalf("expected another node with a new cookie, got %s %v", got, reissued)
+	}
+	// the tampered cookie is ignored
+	if _, reissued := route(nodes, &http.Cookie{Name: cookie.Name, Value: "MTAuMC4wLjE6ODAwMA.invalid"}); reissued == nil {
+		t.Fatal("expected the tampered cookie to be replaced")
+	}
+	// the cookie of another endpoint is ignored
+	other := &http.Cookie{Name: cookie.Name, Value: (&signer{secret: []byte("secret")}).sign("  /bar", "10.0.0.2:8000")}
+	if got, reissued := route(nodes, other); got == "10.0.0.2:8000" || reissued == nil {
+		t.Fatalf("expected the cookie of another endpoint to be replaced, got %s %v", got, reissued)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	s := &signer{secret: []byte("secret")}
+	if addr, ok := s.verify("/foo", s.sign("/foo", "127.0.0.1:9000")); !ok || addr != "127.0.0.1:9000" {
+		t.Fatalf("expected the signed address to be verified, got %s", addr)
+	}
+	if _, ok := (&signer{secret: []byte("other")}).verify("/foo", s.sign("/foo", "127.0.0.1:9000")); ok {
+		t.Fatal("expected the signature of another secret to be rejected")
+	}
+	if _, ok := s.verify("/bar", s.sign("/foo", "127.0.0.1:9000")); ok {
+		t.Fatal("expected the signature of another endpoint to be rejected")
+	}
+}
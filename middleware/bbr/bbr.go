@@ -1,37 +1,209 @@
 package bbr
 
 import (
-	"bytes"
+	"errors"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-kratos/aegis/ratelimit"
 	"github.com/go-kratos/aegis/ratelimit/bbr"
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/bbr/v1"
 	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
-var _nopBody = io.NopCloser(&bytes.Buffer{})
+// the limiter statistics are reported at most once per interval by each endpoint
+var _statInterval = time.Second
+
+var (
+	_metricDroppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_bbr_dropped_total",
+		Help:      "The total number of requests dropped by the bbr limiter",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "bbr_inflight",
+		Help:      "The inflight requests of the bbr limiter",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricMaxInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "bbr_max_inflight",
+		Help:      "The estimated max inflight requests of the bbr limiter",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricMaxPass = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "bbr_max_pass",
+		Help:      "The estimated max passed requests per bucket of the bbr limiter",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricMinRT = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "bbr_min_rt_milliseconds",
+		Help:      "The estimated min response time of the bbr limiter",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricCPU = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "bbr_cpu",
+		Help:      "The cpu usage in per mille seen by the bbr limiter",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+)
 
 func init() {
-	middleware.Register("bbr", Middleware)
-}
-
-func Middleware(c *config.Middleware) (middleware.Middleware, error) {
-	limiter := bbr.NewLimiter() //use default settings
-	return func(next http.RoundTripper) http.RoundTripper {
-		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
-			done, err := limiter.Allow()
-			if err != nil {
-				return &http.Response{
-					Status:     http.StatusText(http.StatusTooManyRequests),
-					StatusCode: http.StatusTooManyRequests,
-					Body:       _nopBody,
-				}, nil
+	prometheus.MustRegister(_metricDroppedTotal, _metricInFlight, _metricMaxInFlight, _metricMaxPass, _metricMinRT, _metricCPU)
+	middleware.RegisterV2("bbr", Middleware)
+}
+
+type limiter interface {
+	Allow() (ratelimit.DoneFunc, error)
+	Stat() bbr.Stat
+}
+
+// sharedLimiter is shared by the endpoints with the same options.
+type sharedLimiter struct {
+	limiter
+	key  string
+	refs int
+}
+
+var _sharedLimiters = struct {
+	lock sync.Mutex
+	m    map[string]*sharedLimiter
+}{m: make(map[string]*sharedLimiter)}
+
+func acquireLimiter(options *v1.BBR) (*sharedLimiter, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	key := string(b)
+	_sharedLimiters.lock.Lock()
+	defer _sharedLimiters.lock.Unlock()
+	if l, ok := _sharedLimiters.m[key]; ok {
+		l.refs++
+		return l, nil
+	}
+	l := &sharedLimiter{limiter: newLimiter(options), key: key, refs: 1}
+	_sharedLimiters.m[key] = l
+	return l, nil
+}
+
+// Close releases the limiter, it's removed after all the endpoints are closed.
+func (l *sharedLimiter) Close() error {
+	_sharedLimiters.lock.Lock()
+	defer _sharedLimiters.lock.Unlock()
+	l.refs--
+	if l.refs <= 0 {
+		delete(_sharedLimiters.m, l.key)
+	}
+	return nil
+}
+
+func newLimiter(options *v1.BBR) *bbr.BBR {
+	var opts []bbr.Option
+	if options.Window != nil && options.Window.AsDuration() > 0 {
+		opts = append(opts, bbr.WithWindow(options.Window.AsDuration()))
+	}
+	if options.Bucket > 0 {
+		opts = append(opts, bbr.WithBucket(int(options.Bucket)))
+	}
+	if options.CpuThreshold > 0 {
+		opts = append(opts, bbr.WithCPUThreshold(options.CpuThreshold))
+	}
+	if options.CpuQuota > 0 {
+		opts = append(opts, bbr.WithCPUQuota(options.CpuQuota))
+	}
+	return bbr.NewLimiter(opts...)
+}
+
+// statReporter drops the requests by the limiter and reports the limiter statistics of the endpoint,
+// the statistics of the endpoint are deleted on close.
+type statReporter struct {
+	limiter
+	closer     io.Closer
+	lastReport atomic.Int64
+	values     atomic.Pointer[[]string]
+}
+
+func (r *statReporter) report(labels middleware.MetricsLabels) {
+	values := []string{labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath()}
+	r.values.Store(&values)
+	stat := r.Stat()
+	_metricInFlight.WithLabelValues(values...).Set(float64(stat.InFlight))
+	_metricMaxInFlight.WithLabelValues(values...).Set(float64(stat.MaxInFlight))
+	_metricMaxPass.WithLabelValues(values...).Set(float64(stat.MaxPass))
+	_metricMinRT.WithLabelValues(values...).Set(float64(stat.MinRt))
+	_metricCPU.WithLabelValues(values...).Set(float64(stat.CPU))
+}
+
+func (r *statReporter) Close() error {
+	if values := r.values.Load(); values != nil {
+		for _, gauge := range []*prometheus.GaugeVec{_metricInFlight, _metricMaxInFlight, _metricMaxPass, _metricMinRT, _metricCPU} {
+			gauge.DeleteLabelValues(*values...)
+		}
+	}
+	return r.closer.Close()
+}
+
+func (r *statReporter) middleware(next http.RoundTripper) http.RoundTripper {
+	return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		labels, hasLabels := middleware.MetricsLabelsFromContext(req.Context())
+		if now, last := time.Now().UnixNano(), r.lastReport.Load(); hasLabels && now-last >= int64(_statInterval) && r.lastReport.CompareAndSwap(last, now) {
+			r.report(labels)
+		}
+		done, err := r.Allow()
+		if err != nil {
+			if hasLabels {
+				_metricDroppedTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath()).Inc()
 			}
-			resp, err := next.RoundTrip(req)
-			done(ratelimit.DoneInfo{Err: err})
-			return resp, err
-		})
-	}, nil
+			return middleware.NewErrorResponse(req, http.StatusTooManyRequests, "overloaded"), nil
+		}
+		resp, err := next.RoundTrip(req)
+		done(ratelimit.DoneInfo{Err: err})
+		return resp, err
+	})
+}
+
+// Middleware drops the requests adaptively by the cpu usage and the estimated capacity.
+func Middleware(c *config.Middleware) (middleware.MiddlewareV2, error) {
+	options := &v1.BBR{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.CpuThreshold < 0 || options.CpuThreshold > 1000 {
+		return nil, errors.New("bbr cpu threshold must be in [0, 1000]")
+	}
+	var (
+		l      limiter
+		closer io.Closer = middleware.NopCloser
+	)
+	switch options.Scope {
+	case v1.BBR_SHARED:
+		if options.Name == "" {
+			return nil, errors.New("bbr name is required by the shared scope")
+		}
+		shared, err := acquireLimiter(options)
+		if err != nil {
+			return nil, err
+		}
+		l = shared
+		closer = shared
+	default:
+		l = newLimiter(options)
+	}
+	r := &statReporter{limiter: l, closer: closer}
+	return middleware.NewWithCloser(r.middleware, r), nil
 }
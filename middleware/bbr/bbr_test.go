@@ -0,0 +1,116 @@
+package bbr
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/aegis/ratelimit"
+	"github.com/go-kratos/aegis/ratelimit/bbr"
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/bbr/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *v1.BBR
+		valid   bool
+	}{
+		{name: "endpoint", options: &v1.BBR{}, valid: true},
+		{name: "shared", options: &v1.BBR{Scope: v1.BBR_SHARED, Name: "options"}, valid: true},
+		{name: "shared without name", options: &v1.BBR{Scope: v1.BBR_SHARED}},
+		{name: "cpu threshold", options: &v1.BBR{CpuThreshold: 1001}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := anypb.New(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := Middleware(&config.Middleware{Options: options})
+			if (err == nil) != test.valid {
+				t.Fatalf("expected the options to be valid %v, got %v", test.valid, err)
+			}
+			if m != nil {
+				m.Close()
+			}
+		})
+	}
+}
+
+func TestSharedScope(t *testing.T) {
+	options, err := anypb.New(&v1.BBR{Scope: v1.BBR_SHARED, Name: "backend", CpuThreshold: 900})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ms []middleware.MiddlewareV2
+	for i := 0; i < 2; i++ {
+		m, err := Middleware(&config.Middleware{Options: options})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ms = append(ms, m)
+	}
+	if len(_sharedLimiters.m) != 1 {
+		t.Fatalf("expected a shared limiter, got %d", len(_sharedLimiters.m))
+	}
+	next := middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Path: "/"}))
+	resp, err := ms[0].Process(next).RoundTrip(req.WithContext(ctx))
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected request to be allowed, got %v %v", resp, err)
+	}
+	ms[0].Close()
+	if len(_sharedLimiters.m) != 1 {
+		t.Fatal("expected the shared limiter to be kept while in use")
+	}
+	ms[1].Close()
+	if len(_sharedLimiters.m) != 0 {
+		t.Fatal("expected the shared limiter to be removed")
+	}
+}
+
+type rejectLimiter struct{}
+
+func (rejectLimiter) Allow() (ratelimit.DoneFunc, error) { return nil, ratelimit.ErrLimitExceed }
+func (rejectLimiter) Stat() bbr.Stat                     { return bbr.Stat{InFlight: 1} }
+
+func TestRejected(t *testing.T) {
+	r := &statReporter{limiter: rejectLimiter{}, closer: middleware.NopCloser}
+	next := middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("expected the request to be dropped")
+		return nil, nil
+	})
+	request := func(protocol config.Protocol) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Path: "/rejected", Protocol: protocol}))
+		resp, err := r.middleware(next).RoundTrip(req.WithContext(ctx))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	if resp := request(config.Protocol_HTTP); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected too many requests, got %d", resp.StatusCode)
+	}
+	// the trailers-only response of the grpc requests
+	resp := request(config.Protocol_GRPC)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Grpc-Status") != "8" || resp.ContentLength != 0 {
+		t.Fatalf("expected the resource exhausted grpc status, got %d %v", resp.StatusCode, resp.Header)
+	}
+	// the statistics of the endpoint are deleted on close
+	count := testutil.CollectAndCount(_metricInFlight)
+	if r.values.Load() == nil || count == 0 {
+		t.Fatal("expected the statistics to be reported")
+	}
+	r.Close()
+	if n := testutil.CollectAndCount(_metricInFlight); n != count-1 {
+		t.Fatalf("expected the statistics to be deleted on close, got %d of %d", n, count)
+	}
+}
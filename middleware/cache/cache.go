@@ -0,0 +1,496 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/cache/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	headerCache = "X-Cache"
+
+	resultHit         = "HIT"
+	resultMiss        = "MISS"
+	resultStale       = "STALE"
+	resultRevalidated = "REVALIDATED"
+	resultBypass      = "BYPASS"
+)
+
+var (
+	_defaultName              = "default"
+	_defaultMaxBodySize       = int64(1 << 20)
+	_defaultMethods           = []string{http.MethodGet, http.MethodHead}
+	_defaultStatusCodes       = []int32{200, 203, 204, 300, 301, 308, 404, 405, 410, 414, 501}
+	_defaultRevalidateTimeout = 30 * time.Second
+	_metricRequestsCacheTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_cache_total",
+		Help:      "The total number of requests handled by the cache",
+	}, []string{"protocol", "method", "path", "service", "basePath", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricRequestsCacheTotal)
+	middleware.RegisterV2("cache", Middleware)
+}
+
+type cacheControl map[string]string
+
+func parseCacheControl(header http.Header) cacheControl {
+	cc := cacheControl{}
+	for _, line := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(line, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(name string) bool {
+	_, ok := cc[name]
+	return ok
+}
+
+func (cc cacheControl) duration(name string) (time.Duration, bool) {
+	v, ok := cc[name]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+type cache struct {
+	storage              *sharedStorage
+	varyHeaders          []string
+	defaultTTL           time.Duration
+	maxTTL               time.Duration
+	staleWhileRevalidate time.Duration
+	methods              map[string]struct{}
+	statusCodes          map[int]struct{}
+	maxBodySize          int64
+	coalescing           bool
+}
+
+func newCache(options *v1.Cache) (*cache, error) {
+	storage, err := acquireStorage(options)
+	if err != nil {
+		return nil, err
+	}
+	c := &cache{
+		storage:     storage,
+		methods:     make(map[string]struct{}),
+		statusCodes: make(map[int]struct{}),
+		maxBodySize: _defaultMaxBodySize,
+		coalescing:  !options.DisableCoalescing,
+	}
+	for _, h := range options.VaryHeaders {
+		c.varyHeaders = append(c.varyHeaders, textproto.CanonicalMIMEHeaderKey(h))
+	}
+	if options.DefaultTtl != nil {
+		c.defaultTTL = options.DefaultTtl.AsDuration()
+	}
+	if options.MaxTtl != nil {
+		c.maxTTL = options.MaxTtl.AsDuration()
+	}
+	if options.StaleWhileRevalidate != nil {
+		c.staleWhileRevalidate = options.StaleWhileRevalidate.AsDuration()
+	}
+	methods := options.Methods
+	if len(methods) == 0 {
+		methods = _defaultMethods
+	}
+	for _, m := range methods {
+		c.methods[strings.ToUpper(m)] = struct{}{}
+	}
+	statusCodes := options.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = _defaultStatusCodes
+	}
+	for _, code := range statusCodes {
+		c.statusCodes[int(code)] = struct{}{}
+	}
+	if options.MaxBodySize > 0 {
+		c.maxBodySize = options.MaxBodySize
+	}
+	return c, nil
+}
+
+func (c *cache) key(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.Method)
+	b.WriteByte(' ')
+	b.WriteString(req.Host)
+	b.WriteString(req.URL.RequestURI())
+	for _, h := range c.varyHeaders {
+		b.WriteByte('\n')
+		b.WriteString(h)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(req.Header.Values(h), ","))
+	}
+	return b.String()
+}
+
+func (c *cache) bypass(req *http.Request) bool {
+	if endpoint, ok := middleware.EndpointFromContext(req.Context()); ok && endpoint.Protocol == config.Protocol_GRPC {
+		return true
+	}
+	return req.Header.Get("Upgrade") != "" || parseCacheControl(req.Header).has("no-store")
+}
+
+// freshness returns how long the response is fresh and could be served stale,
+// ok is false if the response is not cacheable.
+func (c *cache) freshness(req *http.Request, statusCode int, header http.Header) (ttl, stale time.Duration, ok bool) {
+	if _, ok := c.statusCodes[statusCode]; !ok {
+		return 0, 0, false
+	}
+	cc := parseCacheControl(header)
+	if cc.has("no-store") || cc.has("private") || len(header.Values("Set-Cookie")) > 0 {
+		return 0, 0, false
+	}
+	for _, line := range header.Values("Vary") {
+		for _, h := range strings.Split(line, ",") {
+			h = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(h))
+			if h == "" {
+				continue
+			}
+			if h == "*" || !c.varies(h) {
+				return 0, 0, false
+			}
+		}
+	}
+	// the credentials of the request are not in the cache key unless they vary the responses
+	if (req.Header.Get("Authorization") != "" || (req.Header.Get("Cookie") != "" && !c.varies("Cookie"))) &&
+		!cc.has("public") && !cc.has("s-maxage") {
+		return 0, 0, false
+	}
+	if d, ok := cc.duration("s-maxage"); ok {
+		ttl = d
+	} else if d, ok := cc.duration("max-age"); ok {
+		ttl = d
+	} else if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = time.Now()
+		}
+		ttl = max(expires.Sub(date), 0)
+	} else if header.Get("Expires") != "" {
+		// an invalid Expires means already expired
+		ttl = 0
+	} else if c.defaultTTL > 0 {
+		ttl = c.defaultTTL
+	} else if !cc.has("no-cache") {
+		return 0, 0, false
+	}
+	if age, err := strconv.ParseInt(header.Get("Age"), 10, 64); err == nil && age > 0 {
+		ttl = max(ttl-time.Duration(age)*time.Second, 0)
+	}
+	if c.maxTTL > 0 && ttl > c.maxTTL {
+		ttl = c.maxTTL
+	}
+	stale = c.staleWhileRevalidate
+	if d, ok := cc.duration("stale-while-revalidate"); ok {
+		stale = d
+	}
+	if cc.has("no-cache") {
+		ttl = 0
+		stale = 0
+	}
+	if cc.has("must-revalidate") || cc.has("proxy-revalidate") {
+		stale = 0
+	}
+	if ttl <= 0 && stale <= 0 && header.Get("Etag") == "" && header.Get("Last-Modified") == "" {
+		// nothing to serve or revalidate with
+		return 0, 0, false
+	}
+	return ttl, stale, true
+}
+
+func (c *cache) varies(header string) bool {
+	for _, h := range c.varyHeaders {
+		if h == header {
+			return true
+		}
+	}
+	return false
+}
+
+func notModified(req *http.Request, entry *Entry) bool {
+	if entry.StatusCode != http.StatusOK {
+		return false
+	}
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		etag := strings.TrimPrefix(entry.Header.Get("Etag"), "W/")
+		if etag == "" {
+			return false
+		}
+		for _, v := range strings.Split(inm, ",") {
+			v = strings.TrimSpace(v)
+			if v == "*" || strings.TrimPrefix(v, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ims, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lm, err := http.ParseTime(entry.Header.Get("Last-Modified"))
+	return err == nil && !lm.After(ims)
+}
+
+func (c *cache) serve(req *http.Request, entry *Entry, result string) *http.Response {
+	header := entry.Header.Clone()
+	header.Set("Age", strconv.FormatInt(int64(time.Since(entry.StoredAt).Seconds()), 10))
+	header.Set(headerCache, result)
+	statusCode, body := entry.StatusCode, entry.Body
+	if notModified(req, entry) {
+		statusCode, body = http.StatusNotModified, nil
+		header.Del("Content-Length")
+	}
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (c *cache) newEntry(req *http.Request, key string, statusCode int, header http.Header, body []byte, ttl, stale time.Duration) *Entry {
+	now := time.Now()
+	return &Entry{
+		Key:        key,
+		Host:       req.Host,
+		Path:       req.URL.Path,
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       body,
+		StoredAt:   now,
+		Expires:    now.Add(ttl),
+		StaleUntil: now.Add(ttl + stale),
+	}
+}
+
+// readBody reads the body up to limit, complete is false if the body is larger.
+func readBody(body io.ReadCloser, limit int64) (data []byte, complete bool, err error) {
+	if body == nil || body == http.NoBody {
+		return nil, true, nil
+	}
+	data, err = io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	return data, int64(len(data)) <= limit, nil
+}
+
+// forward sends the request to upstream, and revalidates the stale entry if any.
+func (c *cache) forward(req *http.Request, next http.RoundTripper, key string, stale *Entry) (*http.Response, *Entry, error) {
+	outreq := req
+	if stale != nil && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		etag, lm := stale.Header.Get("Etag"), stale.Header.Get("Last-Modified")
+		if etag != "" || lm != "" {
+			outreq = req.Clone(req.Context())
+			if etag != "" {
+				outreq.Header.Set("If-None-Match", etag)
+			}
+			if lm != "" {
+				outreq.Header.Set("If-Modified-Since", lm)
+			}
+		}
+	}
+	resp, err := next.RoundTrip(outreq)
+	if err != nil {
+		return nil, nil, err
+	}
+	if outreq != req && resp.StatusCode == http.StatusNotModified {
+		if resp.Body != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		header := stale.Header.Clone()
+		for k, vs := range resp.Header {
+			if k == "Content-Length" || k == "Transfer-Encoding" {
+				continue
+			}
+			header[k] = vs
+		}
+		ttl, staleTTL, ok := c.freshness(req, stale.StatusCode, header)
+		entry := c.newEntry(req, key, stale.StatusCode, header, stale.Body, ttl, staleTTL)
+		if !ok {
+			c.storage.Delete(key)
+			return c.serve(req, entry, resultRevalidated), nil, nil
+		}
+		c.storage.Set(entry)
+		return c.serve(req, entry, resultRevalidated), entry, nil
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Header.Set(headerCache, resultMiss)
+	ttl, staleTTL, ok := c.freshness(req, resp.StatusCode, resp.Header)
+	if !ok {
+		if stale != nil {
+			c.storage.Delete(key)
+		}
+		return resp, nil, nil
+	}
+	body, complete, err := readBody(resp.Body, c.maxBodySize)
+	if err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	if !complete {
+		resp.Body = &middleware.MultiReadCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil, nil
+	}
+	if resp.Body != nil {
+		resp.Body.Close()
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	header := resp.Header.Clone()
+	header.Del(headerCache)
+	entry := c.newEntry(req, key, resp.StatusCode, header, body, ttl, staleTTL)
+	c.storage.Set(entry)
+	return resp, entry, nil
+}
+
+// fetch forwards the request, the concurrent requests of the same key wait for the leader.
+func (c *cache) fetch(req *http.Request, next http.RoundTripper, key string, stale *Entry) (*http.Response, error) {
+	if !c.coalescing {
+		resp, _, err := c.forward(req, next, key, stale)
+		return resp, err
+	}
+	call, leader := c.storage.calls.Begin(key)
+	if !leader {
+		select {
+		case <-call.Done():
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if entry := call.Value(); entry != nil {
+			return c.serve(req, entry, resultHit), nil
+		}
+		// the response of the leader is not cached
+		resp, _, err := c.forward(req, next, key, stale)
+		return resp, err
+	}
+	var entry *Entry
+	defer func() { c.storage.calls.End(key, call, entry) }()
+	resp, entry, err := c.forward(req, next, key, stale)
+	return resp, err
+}
+
+// revalidate refreshes the stale entry in background, the response is served stale meanwhile.
+func (c *cache) revalidate(req *http.Request, next http.RoundTripper, key string, stale *Entry) {
+	call, leader := c.storage.calls.Begin(key)
+	if !leader {
+		return
+	}
+	endpoint, _ := middleware.EndpointFromContext(req.Context())
+	go func() {
+		var entry *Entry
+		defer func() { c.storage.calls.End(key, call, entry) }()
+		timeout := _defaultRevalidateTimeout
+		if endpoint.GetTimeout() != nil && endpoint.GetTimeout().AsDuration() > 0 {
+			timeout = endpoint.GetTimeout().AsDuration()
+		}
+		// the request options are owned by the served request
+		reqOpts := middleware.NewRequestOptions(endpoint)
+		ctx, cancel := context.WithTimeout(middleware.NewRequestContext(context.WithoutCancel(req.Context()), reqOpts), timeout)
+		defer cancel()
+		outreq := req.Clone(ctx)
+		outreq.Body, outreq.GetBody, outreq.ContentLength = http.NoBody, nil, 0
+		resp, stored, err := c.forward(outreq, next, key, stale)
+		if err != nil {
+			reqOpts.DoneFunc(ctx, selector.DoneInfo{Err: err})
+			log.Warnf("failed to revalidate the cached response of %s: %v", key, err)
+			return
+		}
+		entry = stored
+		if resp.Body != nil {
+			_, err = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		reqOpts.DoneFunc(ctx, selector.DoneInfo{Err: err})
+	}()
+}
+
+func requestsCacheIncr(req *http.Request, result string) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricRequestsCacheTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), result).Inc()
+	}
+}
+
+// Middleware caches the upstream responses by the HTTP caching semantics.
+func Middleware(c *config.Middleware) (middleware.MiddlewareV2, error) {
+	options := &v1.Cache{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	cache, err := newCache(options)
+	if err != nil {
+		return nil, err
+	}
+	return middleware.NewWithCloser(func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if _, ok := cache.methods[req.Method]; !ok {
+				return next.RoundTrip(req)
+			}
+			if cache.bypass(req) {
+				requestsCacheIncr(req, resultBypass)
+				return next.RoundTrip(req)
+			}
+			key := cache.key(req)
+			entry, ok := cache.storage.Get(key)
+			if ok && !parseCacheControl(req.Header).has("no-cache") {
+				now := time.Now()
+				if now.Before(entry.Expires) {
+					requestsCacheIncr(req, resultHit)
+					return cache.serve(req, entry, resultHit), nil
+				}
+				if now.Before(entry.StaleUntil) {
+					cache.revalidate(req, next, key, entry)
+					requestsCacheIncr(req, resultStale)
+					return cache.serve(req, entry, resultStale), nil
+				}
+			}
+			resp, err := cache.fetch(req, next, key, entry)
+			if err != nil {
+				return nil, err
+			}
+			requestsCacheIncr(req, resp.Header.Get(headerCache))
+			return resp, nil
+		})
+	}, cache.storage), nil
+}
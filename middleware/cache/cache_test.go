@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/cache/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type upstream struct {
+	calls   atomic.Int64
+	handler func(req *http.Request, n int64) *http.Response
+}
+
+func (u *upstream) RoundTrip(req *http.Request) (*http.Response, error) {
+	return u.handler(req, u.calls.Add(1)), nil
+}
+
+func newResponse(statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: statusCode, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func do(t *testing.T, rt http.RoundTripper, header http.Header) (*http.Response, string) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/api/items?page=1", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Path: "/api/*", Protocol: config.Protocol_HTTP}))
+	resp, err := rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return resp, string(body)
+}
+
+func TestCache(t *testing.T) {
+	type step struct {
+		header http.Header
+		status int
+		result string
+		body   string
+		// the result is polled until the body is expected
+		eventually bool
+	}
+	tests := []struct {
+		name    string
+		options *v1.Cache
+		handler func(req *http.Request, n int64) *http.Response
+		steps   []step
+		calls   int64
+	}{
+		{
+			name:    "vary",
+			options: &v1.Cache{VaryHeaders: []string{"accept-language"}},
+			handler: func(req *http.Request, n int64) *http.Response {
+				return newResponse(http.StatusOK, http.Header{
+					"Cache-Control": {"max-age=60"},
+					"Vary":          {"Accept-Language"},
+				}, "v"+strconv.FormatInt(n, 10))
+			},
+			steps: []step{
+				{result: resultMiss, body: "v1"},
+				{result: resultHit, body: "v1"},
+				{header: http.Header{"Accept-Language": {"fr"}}, result: resultMiss, body: "v2"},
+				{header: http.Header{"Cache-Control": {"no-store"}}, body: "v3"},
+			},
+			calls: 3,
+		},
+		{
+			name:    "private",
+			options: &v1.Cache{DefaultTtl: durationpb.New(time.Minute)},
+			handler: func(req *http.Request, n int64) *http.Response {
+				return newResponse(http.StatusOK, http.Header{"Cache-Control": {"private, max-age=60"}}, "")
+			},
+			steps: []step{{result: resultMiss}, {result: resultMiss}},
+			calls: 2,
+		},
+		{
+			name:    "revalidate",
+			options: &v1.Cache{},
+			handler: func(req *http.Request, n int64) *http.Response {
+				if req.Header.Get("If-None-Match") == `"v1"` {
+					return newResponse(http.StatusNotModified, http.Header{"Cache-Control": {"max-age=60"}}, "")
+				}
+				return newResponse(http.StatusOK, http.Header{"Cache-Control": {"no-cache"}, "Etag": {`"v1"`}}, "body")
+			},
+			steps: []step{
+				{result: resultMiss, body: "body"},
+				{result: resultRevalidated, body: "body"},
+				{result: resultHit, body: "body"},
+				// not modified for the client validator
+				{header: http.Header{"If-None-Match": {`W/"v1"`}}, status: http.StatusNotModified, result: resultHit},
+			},
+			calls: 2,
+		},
+		{
+			name:    "stale while revalidate",
+			options: &v1.Cache{},
+			handler: func(req *http.Request, n int64) *http.Response {
+				return newResponse(http.StatusOK, http.Header{"Cache-Control": {"max-age=0, stale-while-revalidate=60"}}, "v"+strconv.FormatInt(n, 10))
+			},
+			steps: []step{
+				{result: resultMiss, body: "v1"},
+				{result: resultStale, body: "v1"},
+				// refreshed in background
+				{result: resultStale, body: "v2", eventually: true},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.options.Name = t.Name()
+			options, err := anypb.New(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := Middleware(&config.Middleware{Options: options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer m.Close()
+			u := &upstream{handler: test.handler}
+			rt := m.Process(u)
+			for i, s := range test.steps {
+				deadline := time.Now().Add(5 * time.Second)
+				resp, body := do(t, rt, s.header)
+				for s.eventually && body != s.body && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+					resp, body = do(t, rt, s.header)
+				}
+				if resp.Header.Get(headerCache) != s.result || body != s.body {
+					t.Fatalf("step %d: expected %q %q, got %q %q", i, s.result, s.body, resp.Header.Get(headerCache), body)
+				}
+				if s.status != 0 && resp.StatusCode != s.status {
+					t.Fatalf("step %d: expected status code %d, got %d", i, s.status, resp.StatusCode)
+				}
+			}
+			if test.calls > 0 && u.calls.Load() != test.calls {
+				t.Fatalf("expected %d upstream calls, got %d", test.calls, u.calls.Load())
+			}
+		})
+	}
+}
+
+func TestCoalescing(t *testing.T) {
+	release := make(chan struct{})
+	u := &upstream{handler: func(req *http.Request, n int64) *http.Response {
+		<-release
+		return newResponse(http.StatusOK, http.Header{"Cache-Control": {"max-age=60"}}, "body")
+	}}
+	options, err := anypb.New(&v1.Cache{Name: t.Name()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	rt := m.Process(u)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, body := do(t, rt, nil); body != "body" {
+				t.Errorf("unexpected body: %s", body)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if u.calls.Load() != 1 {
+		t.Fatalf("expected the concurrent requests to be coalesced, got %d calls", u.calls.Load())
+	}
+}
+
+func TestPurge(t *testing.T) {
+	u := &upstream{handler: func(req *http.Request, n int64) *http.Response {
+		return newResponse(http.StatusOK, http.Header{"Cache-Control": {"max-age=60"}}, "body")
+	}}
+	options, err := anypb.New(&v1.Cache{Name: t.Name(), Storage: &v1.Cache_Disk{Disk: &v1.DiskStorage{Dir: t.TempDir()}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	rt := m.Process(u)
+	do(t, rt, nil)
+
+	handler := debugger{}.DebugHandler()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/debug/cache/purge?name="+t.Name()+"&path=/other", nil))
+	if w.Body.String() != "{\"purged\":0}\n" {
+		t.Fatalf("expected nothing to be purged, got %s", w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/debug/cache/purge?name="+t.Name()+"&host=example.com&path=/api/", nil))
+	if w.Body.String() != "{\"purged\":1}\n" {
+		t.Fatalf("expected the entry to be purged, got %s", w.Body.String())
+	}
+	if resp, _ := do(t, rt, nil); resp.Header.Get(headerCache) != resultMiss {
+		t.Fatalf("expected a miss after purge, got %s", resp.Header.Get(headerCache))
+	}
+}
+
+func TestDiskStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newDiskStorage(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Set(&Entry{Key: "a", StatusCode: http.StatusOK, Header: http.Header{"Etag": {"1"}}, Body: []byte("body"), StoredAt: time.Now()})
+	// the entries are restored from the files
+	s, err = newDiskStorage(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := s.Get("a")
+	if !ok || string(entry.Body) != "body" || entry.Header.Get("Etag") != "1" {
+		t.Fatalf("expected the entry to be restored, got %+v", entry)
+	}
+}
+
+func TestMemoryStorageEviction(t *testing.T) {
+	s := newMemoryStorage(10)
+	s.Set(&Entry{Key: "a", Body: []byte("1234")})
+	s.Set(&Entry{Key: "b", Body: []byte("1234")})
+	s.Get("a")
+	s.Set(&Entry{Key: "c", Body: []byte("1234")})
+	if _, ok := s.Get("b"); ok {
+		t.Fatal("expected the least recently used entry to be evicted")
+	}
+	if entries, size := s.Stats(); entries != 2 || size != 10 {
+		t.Fatalf("expected 2 entries of 10 bytes, got %d %d", entries, size)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		varyHeaders []string
+		request     http.Header
+		response    http.Header
+		cacheable   bool
+	}{
+		{"anonymous", nil, nil, http.Header{"Cache-Control": {"max-age=60"}}, true},
+		{"authorization", nil, http.Header{"Authorization": {"Bearer t"}}, http.Header{"Cache-Control": {"max-age=60"}}, false},
+		{"authorization public", nil, http.Header{"Authorization": {"Bearer t"}}, http.Header{"Cache-Control": {"public, max-age=60"}}, true},
+		{"cookie", nil, http.Header{"Cookie": {"session=1"}}, http.Header{"Cache-Control": {"max-age=60"}}, false},
+		{"cookie s-maxage", nil, http.Header{"Cookie": {"session=1"}}, http.Header{"Cache-Control": {"s-maxage=60"}}, true},
+		{"cookie vary", []string{"Cookie"}, http.Header{"Cookie": {"session=1"}}, http.Header{"Cache-Control": {"max-age=60"}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &cache{statusCodes: map[int]struct{}{http.StatusOK: {}}, varyHeaders: test.varyHeaders}
+			req := &http.Request{Header: test.request}
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+			if _, _, ok := c.freshness(req, http.StatusOK, test.response); ok != test.cacheable {
+				t.Fatalf("expected cacheable %v, got %v", test.cacheable, ok)
+			}
+		})
+	}
+}
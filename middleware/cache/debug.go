@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/gateway/proxy/debug"
+)
+
+func init() {
+	debug.Register("cache", debugger{})
+}
+
+type debugger struct{}
+
+type inspectStorage struct {
+	Name    string `json:"name"`
+	Storage string `json:"storage"`
+	Entries int    `json:"entries"`
+	Size    int64  `json:"size"`
+}
+
+// DebugHandler serves the stats and purge of the caches:
+//
+//	GET /debug/cache/stats?name=
+//	POST /debug/cache/purge?name=&host=&path=&key=
+//
+// the purge without conditions deletes all the entries, the path is matched by prefix.
+func (debugger) DebugHandler() http.Handler {
+	debugMux := http.NewServeMux()
+	debugMux.HandleFunc("/debug/cache/stats", func(w http.ResponseWriter, r *http.Request) {
+		var stats []*inspectStorage
+		for _, s := range storages(r.URL.Query().Get("name")) {
+			entries, size := s.Stats()
+			stats = append(stats, &inspectStorage{Name: s.name, Storage: s.kind, Entries: entries, Size: size})
+		}
+		sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(stats)
+	})
+	debugMux.HandleFunc("/debug/cache/purge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		host, path, key := query.Get("host"), query.Get("path"), query.Get("key")
+		match := func(e *Entry) bool {
+			return (host == "" || e.Host == host) &&
+				(path == "" || strings.HasPrefix(e.Path, path)) &&
+				(key == "" || e.Key == key)
+		}
+		var purged int
+		for _, s := range storages(query.Get("name")) {
+			purged += s.Purge(match)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"purged": purged})
+	})
+	return debugMux
+}
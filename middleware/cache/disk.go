@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var _defaultDiskMaxSize = int64(1 << 30)
+
+const _diskFileSuffix = ".cache"
+
+// diskIndex is the entry without the header and body in memory.
+type diskIndex struct {
+	entry *Entry
+	file  string
+	size  int64
+}
+
+// diskStorage keeps the entries in files, the index is kept in memory as a size limited LRU.
+type diskStorage struct {
+	lock    sync.Mutex
+	dir     string
+	maxSize int64
+	size    int64
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+func newDiskStorage(dir string, maxSize int64) (*diskStorage, error) {
+	if dir == "" {
+		return nil, errors.New("cache dir is required")
+	}
+	if maxSize <= 0 {
+		maxSize = _defaultDiskMaxSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &diskStorage{
+		dir:     dir,
+		maxSize: maxSize,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load restores the index of the files, the recently stored entries are kept first.
+func (s *diskStorage) load() error {
+	// the partial writes of the last run
+	tmps, _ := filepath.Glob(filepath.Join(s.dir, "tmp-*"))
+	for _, tmp := range tmps {
+		_ = os.Remove(tmp)
+	}
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+_diskFileSuffix))
+	if err != nil {
+		return err
+	}
+	var indexes []*diskIndex
+	for _, file := range files {
+		entry, err := readEntry(file)
+		if err != nil {
+			log.Warnf("remove the invalid cache file %s: %v", file, err)
+			_ = os.Remove(file)
+			continue
+		}
+		indexes = append(indexes, &diskIndex{entry: metadata(entry), file: file, size: entry.Size()})
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].entry.StoredAt.After(indexes[j].entry.StoredAt)
+	})
+	for _, idx := range indexes {
+		if _, ok := s.entries[idx.entry.Key]; ok || s.size+idx.size > s.maxSize {
+			_ = os.Remove(idx.file)
+			continue
+		}
+		s.entries[idx.entry.Key] = s.ll.PushBack(idx)
+		s.size += idx.size
+	}
+	return nil
+}
+
+func metadata(entry *Entry) *Entry {
+	return &Entry{
+		Key:        entry.Key,
+		Host:       entry.Host,
+		Path:       entry.Path,
+		StatusCode: entry.StatusCode,
+		StoredAt:   entry.StoredAt,
+		Expires:    entry.Expires,
+		StaleUntil: entry.StaleUntil,
+	}
+}
+
+func readEntry(file string) (*Entry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entry := &Entry{}
+	if err := gob.NewDecoder(f).Decode(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *diskStorage) filename(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+_diskFileSuffix)
+}
+
+func (s *diskStorage) Get(key string) (*Entry, bool) {
+	s.lock.Lock()
+	el, ok := s.entries[key]
+	if !ok {
+		s.lock.Unlock()
+		return nil, false
+	}
+	s.ll.MoveToFront(el)
+	file := el.Value.(*diskIndex).file
+	s.lock.Unlock()
+
+	entry, err := readEntry(file)
+	if err != nil || entry.Key != key {
+		s.Delete(key)
+		return nil, false
+	}
+	return entry, true
+}
+
+func (s *diskStorage) Set(entry *Entry) {
+	size := entry.Size()
+	if size > s.maxSize {
+		return
+	}
+	file := s.filename(entry.Key)
+	tmp, err := os.CreateTemp(s.dir, "tmp-*")
+	if err != nil {
+		log.Errorf("failed to create cache file: %v", err)
+		return
+	}
+	err = gob.NewEncoder(tmp).Encode(entry)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		log.Errorf("failed to write cache file: %v", err)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		_ = os.Remove(tmp.Name())
+		log.Errorf("failed to write cache file: %v", err)
+		return
+	}
+	if el, ok := s.entries[entry.Key]; ok {
+		s.size -= el.Value.(*diskIndex).size
+		s.ll.Remove(el)
+	}
+	s.entries[entry.Key] = s.ll.PushFront(&diskIndex{entry: metadata(entry), file: file, size: size})
+	s.size += size
+	for s.size > s.maxSize {
+		s.remove(s.ll.Back())
+	}
+}
+
+func (s *diskStorage) remove(el *list.Element) {
+	idx := s.ll.Remove(el).(*diskIndex)
+	delete(s.entries, idx.entry.Key)
+	s.size -= idx.size
+	if err := os.Remove(idx.file); err != nil && !os.IsNotExist(err) {
+		log.Errorf("failed to remove cache file: %v", err)
+	}
+}
+
+func (s *diskStorage) Delete(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.remove(el)
+	}
+}
+
+func (s *diskStorage) Purge(match func(*Entry) bool) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var purged int
+	for el := s.ll.Front(); el != nil; {
+		next := el.Next()
+		if match(el.Value.(*diskIndex).entry) {
+			s.remove(el)
+			purged++
+		}
+		el = next
+	}
+	return purged
+}
+
+func (s *diskStorage) Stats() (int, int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.entries), s.size
+}
+
+// Close keeps the files for the next start.
+func (s *diskStorage) Close() error { return nil }
@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/cache/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+)
+
+var _defaultMemoryMaxSize = int64(64 << 20)
+
+// Entry is a cached response.
+type Entry struct {
+	Key        string
+	Host       string
+	Path       string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// the time the response was received or revalidated
+	StoredAt time.Time
+	// the response is fresh until Expires
+	Expires time.Time
+	// the stale response could be served while revalidating until StaleUntil
+	StaleUntil time.Time
+}
+
+// Size returns the approximate bytes of the entry.
+func (e *Entry) Size() int64 {
+	size := int64(len(e.Key) + len(e.Host) + len(e.Path) + len(e.Body))
+	for k, vs := range e.Header {
+		size += int64(len(k))
+		for _, v := range vs {
+			size += int64(len(v))
+		}
+	}
+	return size
+}
+
+// Storage keeps the cached responses.
+type Storage interface {
+	Get(key string) (*Entry, bool)
+	Set(entry *Entry)
+	Delete(key string)
+	// Purge deletes the entries matched, and returns the count of deleted entries,
+	// the header and body of the entries to match may be absent.
+	Purge(match func(*Entry) bool) int
+	// Stats returns the count and bytes of the entries.
+	Stats() (entries int, size int64)
+	Close() error
+}
+
+// memoryStorage is a size limited LRU.
+type memoryStorage struct {
+	lock    sync.Mutex
+	maxSize int64
+	size    int64
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+func newMemoryStorage(maxSize int64) *memoryStorage {
+	if maxSize <= 0 {
+		maxSize = _defaultMemoryMaxSize
+	}
+	return &memoryStorage{
+		maxSize: maxSize,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (s *memoryStorage) Get(key string) (*Entry, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.ll.MoveToFront(el)
+	return el.Value.(*Entry), true
+}
+
+func (s *memoryStorage) Set(entry *Entry) {
+	size := entry.Size()
+	if size > s.maxSize {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if el, ok := s.entries[entry.Key]; ok {
+		s.remove(el)
+	}
+	s.entries[entry.Key] = s.ll.PushFront(entry)
+	s.size += size
+	for s.size > s.maxSize {
+		s.remove(s.ll.Back())
+	}
+}
+
+func (s *memoryStorage) remove(el *list.Element) {
+	entry := s.ll.Remove(el).(*Entry)
+	delete(s.entries, entry.Key)
+	s.size -= entry.Size()
+}
+
+func (s *memoryStorage) Delete(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if el, ok := s.entries[key]; ok {
+		s.remove(el)
+	}
+}
+
+func (s *memoryStorage) Purge(match func(*Entry) bool) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var purged int
+	for el := s.ll.Front(); el != nil; {
+		next := el.Next()
+		if match(el.Value.(*Entry)) {
+			s.remove(el)
+			purged++
+		}
+		el = next
+	}
+	return purged
+}
+
+func (s *memoryStorage) Stats() (int, int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.entries), s.size
+}
+
+func (s *memoryStorage) Close() error { return nil }
+
+// sharedStorage is shared by the middlewares with the same name and storage,
+// so that the cached responses survive the config reloads.
+type sharedStorage struct {
+	Storage
+	name   string
+	kind   string
+	config string
+	refs   int
+	calls  *middleware.CallGroup[*Entry]
+}
+
+var _storages = struct {
+	lock sync.Mutex
+	m    map[string]*sharedStorage
+}{m: make(map[string]*sharedStorage)}
+
+func newStorage(options *v1.Cache) (string, Storage, error) {
+	switch storage := options.Storage.(type) {
+	case *v1.Cache_Disk:
+		s, err := newDiskStorage(storage.Disk.GetDir(), storage.Disk.GetMaxSize())
+		return "disk", s, err
+	case *v1.Cache_Memory:
+		return "memory", newMemoryStorage(storage.Memory.GetMaxSize()), nil
+	}
+	return "memory", newMemoryStorage(0), nil
+}
+
+func acquireStorage(options *v1.Cache) (*sharedStorage, error) {
+	name := options.Name
+	if name == "" {
+		name = _defaultName
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(&v1.Cache{Name: name, Storage: options.Storage})
+	if err != nil {
+		return nil, err
+	}
+	key := string(b)
+	_storages.lock.Lock()
+	defer _storages.lock.Unlock()
+	if s, ok := _storages.m[key]; ok {
+		s.refs++
+		return s, nil
+	}
+	kind, storage, err := newStorage(options)
+	if err != nil {
+		return nil, err
+	}
+	s := &sharedStorage{
+		Storage: storage,
+		name:    name,
+		kind:    kind,
+		config:  key,
+		refs:    1,
+		calls:   middleware.NewCallGroup[*Entry](),
+	}
+	_storages.m[key] = s
+	return s, nil
+}
+
+// Close releases the storage, it's closed after all the middlewares are closed.
+func (s *sharedStorage) Close() error {
+	_storages.lock.Lock()
+	defer _storages.lock.Unlock()
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+	delete(_storages.m, s.config)
+	return s.Storage.Close()
+}
+
+// storages returns the storages of the name, or all if name is empty.
+func storages(name string) []*sharedStorage {
+	_storages.lock.Lock()
+	defer _storages.lock.Unlock()
+	var list []*sharedStorage
+	for _, s := range _storages.m {
+		if name == "" || s.name == name {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,208 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/canary/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const _defaultRuleName = "default"
+
+var _metricCanaryTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_canary_total",
+	Help:      "The total number of requests routed by the canary rules",
+}, []string{"protocol", "method", "path", "service", "basePath", "rule"})
+
+func init() {
+	prometheus.MustRegister(_metricCanaryTotal)
+	middleware.Register("canary", Middleware)
+}
+
+type matcher func(*http.Request) bool
+
+func newMatcher(m *v1.Match) (matcher, error) {
+	var value func(*http.Request) string
+	switch source := m.GetSource().(type) {
+	case *v1.Match_Header:
+		value = func(req *http.Request) string { return req.Header.Get(source.Header) }
+	case *v1.Match_Cookie:
+		value = func(req *http.Request) string {
+			cookie, err := req.Cookie(source.Cookie)
+			if err != nil {
+				return ""
+			}
+			return cookie.Value
+		}
+	case *v1.Match_Query:
+		value = func(req *http.Request) string { return req.URL.Query().Get(source.Query) }
+	default:
+		return nil, fmt.Errorf("unknown canary match source: %+v", m)
+	}
+	if m.Percentage < 0 || m.Percentage > 100 {
+		return nil, fmt.Errorf("invalid canary match percentage: %v", m.Percentage)
+	}
+	var re *regexp.Regexp
+	if m.Regex != "" {
+		var err error
+		if re, err = regexp.Compile(m.Regex); err != nil {
+			return nil, err
+		}
+	}
+	return func(req *http.Request) bool {
+		v := value(req)
+		if v == "" {
+			return false
+		}
+		switch {
+		case re != nil:
+			if !re.MatchString(v) {
+				return false
+			}
+		case m.Value != "":
+			if v != m.Value {
+				return false
+			}
+		}
+		if m.Percentage > 0 {
+			h := fnv.New32a()
+			h.Write([]byte(v))
+			return float64(h.Sum32()%10000) < m.Percentage*100
+		}
+		return true
+	}, nil
+}
+
+// contains reports whether the node is in the subset.
+func contains(subset *v1.Subset, node selector.Node) bool {
+	if subset.Version != "" && node.Version() != subset.Version {
+		return false
+	}
+	md := node.Metadata()
+	for k, v := range subset.Metadata {
+		if md[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the nodes selected by the function, the nodes are kept if none selected and not strict.
+func filter(nodes []selector.Node, strict bool, selected func(selector.Node) bool) []selector.Node {
+	filtered := make([]selector.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if selected(n) {
+			filtered = append(filtered, n)
+		}
+	}
+	if len(filtered) == 0 && !strict {
+		return nodes
+	}
+	return filtered
+}
+
+type rule struct {
+	name     string
+	matchers []matcher
+	subset   *v1.Subset
+}
+
+func (r *rule) match(req *http.Request) bool {
+	for _, m := range r.matchers {
+		if !m(req) {
+			return false
+		}
+	}
+	return true
+}
+
+func canaryIncr(req *http.Request, rule string) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricCanaryTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), rule).Inc()
+	}
+}
+
+// Middleware routes the requests to the subsets of the nodes by the canary rules.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Canary{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if len(options.Rules) == 0 {
+		return nil, errors.New("at least one canary rule is required")
+	}
+	rules := make([]*rule, 0, len(options.Rules))
+	for i, r := range options.Rules {
+		if r.Subset == nil {
+			return nil, fmt.Errorf("canary rule %d has no subset", i)
+		}
+		name := r.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		matchers := make([]matcher, 0, len(r.Matches))
+		for _, m := range r.Matches {
+			matcher, err := newMatcher(m)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, matcher)
+		}
+		rules = append(rules, &rule{name: name, matchers: matchers, subset: r.Subset})
+	}
+	defaultFilter := func(ctx context.Context, nodes []selector.Node) []selector.Node {
+		if options.DefaultSubset != nil {
+			return filter(nodes, options.Strict, func(n selector.Node) bool {
+				return contains(options.DefaultSubset, n)
+			})
+		}
+		return filter(nodes, options.Strict, func(n selector.Node) bool {
+			for _, r := range rules {
+				if contains(r.subset, n) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the filter added by the first attempt is kept in the request options
+			if middleware.HasAttemptFilters(req.Context()) {
+				return next.RoundTrip(req)
+			}
+			for _, r := range rules {
+				if !r.match(req) {
+					continue
+				}
+				canaryIncr(req, r.name)
+				subset := r.subset
+				middleware.WithSelectorFitler(req.Context(), func(ctx context.Context, nodes []selector.Node) []selector.Node {
+					return filter(nodes, options.Strict, func(n selector.Node) bool {
+						return contains(subset, n)
+					})
+				})
+				return next.RoundTrip(req)
+			}
+			canaryIncr(req, _defaultRuleName)
+			middleware.WithSelectorFitler(req.Context(), defaultFilter)
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
@@ -0,0 +1,112 @@
+package canary
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/canary/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestCanary(t *testing.T) {
+	v, err := anypb.New(&v1.Canary{
+		Rules: []*v1.Rule{{
+			Name: "beta",
+			Matches: []*v1.Match{
+				{Source: &v1.Match_Header{Header: "X-Canary"}, Value: "true"},
+			},
+			Subset: &v1.Subset{Version: "v2"},
+		}, {
+			Name: "tester",
+			Matches: []*v1.Match{
+				{Source: &v1.Match_Cookie{Cookie: "user"}, Regex: "^test-"},
+			},
+			Subset: &v1.Subset{Metadata: map[string]string{"lane": "test"}},
+		}, {
+			Name: "all-users",
+			Matches: []*v1.Match{
+				{Source: &v1.Match_Query{Query: "uid"}, Percentage: 100},
+			},
+			Subset: &v1.Subset{Version: "v3"},
+			// no nodes of v3
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := []selector.Node{
+		selector.NewNode("http", "stable", &registry.ServiceInstance{Version: "v1"}),
+		selector.NewNode("http", "canary", &registry.ServiceInstance{Version: "v2"}),
+		selector.NewNode("http", "lane", &registry.ServiceInstance{Version: "v1", Metadata: map[string]string{"lane": "test"}}),
+	}
+	route := func(req *http.Request) []string {
+		reqOpts := middleware.NewRequestOptions(&config.Endpoint{})
+		req = req.WithContext(middleware.NewRequestContext(req.Context(), reqOpts))
+		var selected []string
+		next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			candidates := append([]selector.Node(nil), nodes...)
+			filters, _ := middleware.SelectorFiltersFromContext(req.Context())
+			for _, f := range filters {
+				candidates = f(context.Background(), candidates)
+			}
+			for _, n := range candidates {
+				selected = append(selected, n.Address())
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		})
+		if _, err := m(next).RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		return selected
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Set("X-Canary", "true")
+	if selected := route(req); len(selected) != 1 || selected[0] != "canary" {
+		t.Fatalf("expected the canary node, got %v", selected)
+	}
+	req, _ = http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.AddCookie(&http.Cookie{Name: "user", Value: "test-alice"})
+	if selected := route(req); len(selected) != 1 || selected[0] != "lane" {
+		t.Fatalf("expected the test lane node, got %v", selected)
+	}
+	req, _ = http.NewRequest(http.MethodGet, "http://example.com/?uid=1", nil)
+	if selected := route(req); len(selected) != 3 {
+		t.Fatalf("expected all the nodes for the empty subset, got %v", selected)
+	}
+	req, _ = http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	if selected := route(req); len(selected) != 1 || selected[0] != "stable" {
+		t.Fatalf("expected the stable node, got %v", selected)
+	}
+}
+
+func TestPercentage(t *testing.T) {
+	match, err := newMatcher(&v1.Match{Source: &v1.Match_Header{Header: "X-User-Id"}, Percentage: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var matched int
+	for i := 0; i < 10000; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		req.Header.Set("X-User-Id", "user-"+strconv.Itoa(i))
+		if match(req) {
+			matched++
+		}
+		// the same user is always matched or not
+		if match(req) != match(req) {
+			t.Fatal("expected the percentage match to be stable")
+		}
+	}
+	if matched < 1500 || matched > 2500 {
+		t.Fatalf("expected about 20%% of the users to be matched, got %d", matched)
+	}
+}
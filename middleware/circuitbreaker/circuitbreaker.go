@@ -2,6 +2,7 @@ package circuitbreaker
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"net/http"
 	"sync"
@@ -27,6 +28,7 @@ var clientBuildContext atomic.Pointer[client.BuildContext]
 func init() {
 	clientBuildContext.Store(client.EmptyBuildContext())
 	prometheus.MustRegister(_metricDeniedTotal)
+	prometheus.MustRegister(_metricStateChangesTotal)
 }
 
 func Init(buildContext *client.BuildContext, clientFactory client.Factory) {
@@ -46,6 +48,12 @@ var (
 		Name:      "requests_circuit_breaker_denied_total",
 		Help:      "The total number of denied requests",
 	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricStateChangesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "circuit_breaker_state_changes_total",
+		Help:      "The total number of state changes of the consecutive failures breakers",
+	}, []string{"protocol", "method", "path", "service", "basePath", "node", "state"})
 )
 
 type ratioTrigger struct {
@@ -124,7 +132,7 @@ func makeOnBreakHandler(buildContext *client.BuildContext, in *v1.CircuitBreaker
 			}
 			resp.Body = io.NopCloser(bytes.NewReader(action.ResponseData.Body))
 			return resp, nil
-		}), io.NopCloser(nil), nil
+		}), middleware.NopCloser, nil
 	default:
 		log.Warnf("Unrecoginzed circuit breaker aciton: %+v", action)
 		return middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
@@ -134,7 +142,7 @@ func makeOnBreakHandler(buildContext *client.BuildContext, in *v1.CircuitBreaker
 				Header:     http.Header{},
 				Body:       io.NopCloser(&bytes.Buffer{}),
 			}, nil
-		}), io.NopCloser(nil), nil
+		}), middleware.NopCloser, nil
 	}
 }
 
@@ -142,6 +150,22 @@ func isSuccessResponse(conditions []condition.Condition, resp *http.Response) bo
 	return condition.JudgeConditons(conditions, resp, true)
 }
 
+// isSuccessError reports whether the failed request is asserted as success,
+// e.g. the canceled requests should not open the breaker.
+func isSuccessError(conditions []condition.Condition, req *http.Request, err error) bool {
+	return condition.JudgeErrorConditons(conditions, req, err, false)
+}
+
+type groupCloser struct {
+	group  *breakerGroup
+	closer io.Closer
+}
+
+func (c *groupCloser) Close() error {
+	c.group.Close()
+	return c.closer.Close()
+}
+
 func deniedRequestIncr(req *http.Request) {
 	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
 	if ok {
@@ -158,15 +182,25 @@ func New(factory client.Factory) middleware.FactoryV2 {
 				return nil, err
 			}
 		}
-		breaker := makeBreakerTrigger(options)
+		consecutive, isConsecutive := options.Trigger.(*v1.CircuitBreaker_ConsecutiveFailures)
+		if options.Scope == v1.Scope_NODE && !isConsecutive {
+			return nil, errors.New("node scope requires the consecutive failures trigger")
+		}
 		onBreakHandler, closer, err := makeOnBreakHandler(clientBuildContext.Load(), options, factory)
 		if err != nil {
 			return nil, err
 		}
 		assertCondtions, err := condition.ParseConditon(options.AssertCondtions...)
 		if err != nil {
+			closer.Close()
 			return nil, err
 		}
+		if isConsecutive {
+			group := newBreakerGroup(options.Scope, newConsecutiveOptions(consecutive.ConsecutiveFailures))
+			registerGroup(group)
+			return middleware.NewWithCloser(group.middleware(onBreakHandler, assertCondtions), &groupCloser{group: group, closer: closer}), nil
+		}
+		breaker := makeBreakerTrigger(options)
 
 		return middleware.NewWithCloser(func(next http.RoundTripper) http.RoundTripper {
 			return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
@@ -180,7 +214,11 @@ func New(factory client.Factory) middleware.FactoryV2 {
 				}
 				resp, err := next.RoundTrip(req)
 				if err != nil {
-					breaker.MarkFailed()
+					if isSuccessError(assertCondtions, req, err) {
+						breaker.MarkSuccess()
+					} else {
+						breaker.MarkFailed()
+					}
 					return nil, err
 				}
 				if !isSuccessResponse(assertCondtions, resp) {
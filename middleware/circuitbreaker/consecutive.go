@@ -0,0 +1,201 @@
+package circuitbreaker
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/circuitbreaker/v1"
+)
+
+const (
+	_defaultConsecutiveFailures = 5
+	_defaultOpenDuration        = 30 * time.Second
+	_defaultHalfOpenRequests    = 1
+)
+
+type state int
+
+const (
+	stateClosed state = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s state) String() string {
+	switch s {
+	case stateClosed:
+		return "closed"
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+type consecutiveOptions struct {
+	failures         int
+	openDuration     time.Duration
+	halfOpenRequests int
+}
+
+func newConsecutiveOptions(in *v1.ConsecutiveFailures) *consecutiveOptions {
+	o := &consecutiveOptions{
+		failures:         int(in.Failures),
+		openDuration:     in.OpenDuration.AsDuration(),
+		halfOpenRequests: int(in.HalfOpenRequests),
+	}
+	if o.failures <= 0 {
+		o.failures = _defaultConsecutiveFailures
+	}
+	if in.OpenDuration == nil {
+		o.openDuration = _defaultOpenDuration
+	}
+	if o.halfOpenRequests <= 0 {
+		o.halfOpenRequests = _defaultHalfOpenRequests
+	}
+	return o
+}
+
+// ticket is the permission of a request, the results of the requests
+// allowed before the state changed are ignored.
+type ticket struct {
+	generation uint64
+	probe      bool
+}
+
+// consecutiveBreaker is the classic closed, open and half-open circuit breaker.
+type consecutiveBreaker struct {
+	opts     *consecutiveOptions
+	onChange func(from, to state)
+	now      func() time.Time
+	// the unix nano when the breaker is used last time
+	lastSeen atomic.Int64
+
+	lock       sync.Mutex
+	state      state
+	generation uint64
+	failures   int
+	probes     int
+	successes  int
+	openUntil  time.Time
+	since      time.Time
+}
+
+func newConsecutiveBreaker(opts *consecutiveOptions, onChange func(from, to state)) *consecutiveBreaker {
+	return &consecutiveBreaker{
+		opts:     opts,
+		onChange: onChange,
+		now:      time.Now,
+		since:    time.Now(),
+	}
+}
+
+// setState must be called with the lock held.
+func (b *consecutiveBreaker) setState(to state, now time.Time) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.failures, b.probes, b.successes = 0, 0, 0
+	b.since = now
+	if to == stateOpen {
+		b.openUntil = now.Add(b.opts.openDuration)
+	}
+	if b.onChange != nil {
+		b.onChange(from, to)
+	}
+}
+
+// available reports whether the breaker allows requests without acquiring a probe.
+func (b *consecutiveBreaker) available() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch b.state {
+	case stateOpen:
+		return !b.now().Before(b.openUntil)
+	case stateHalfOpen:
+		return b.probes < b.opts.halfOpenRequests
+	default:
+		return true
+	}
+}
+
+// allow acquires the permission, it turns half-open once the open duration elapsed.
+func (b *consecutiveBreaker) allow() (ticket, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.now()
+	if b.state == stateOpen {
+		if now.Before(b.openUntil) {
+			return ticket{}, false
+		}
+		b.setState(stateHalfOpen, now)
+	}
+	if b.state == stateHalfOpen {
+		if b.probes >= b.opts.halfOpenRequests {
+			return ticket{}, false
+		}
+		b.probes++
+		return ticket{generation: b.generation, probe: true}, true
+	}
+	return ticket{generation: b.generation}, true
+}
+
+// release returns the probe of the request which is not sent.
+func (b *consecutiveBreaker) release(t ticket) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if t.probe && t.generation == b.generation {
+		b.probes--
+	}
+}
+
+func (b *consecutiveBreaker) mark(t ticket, success bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if t.generation != b.generation {
+		return
+	}
+	now := b.now()
+	switch b.state {
+	case stateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		if b.failures++; b.failures >= b.opts.failures {
+			b.setState(stateOpen, now)
+		}
+	case stateHalfOpen:
+		if !t.probe {
+			return
+		}
+		if !success {
+			b.setState(stateOpen, now)
+			return
+		}
+		b.probes--
+		if b.successes++; b.successes >= b.opts.halfOpenRequests {
+			b.setState(stateClosed, now)
+		}
+	}
+}
+
+type breakerSnapshot struct {
+	State    string    `json:"state"`
+	Failures int       `json:"failures"`
+	Since    time.Time `json:"since"`
+}
+
+func (b *consecutiveBreaker) snapshot() breakerSnapshot {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	s := b.state
+	if s == stateOpen && !b.now().Before(b.openUntil) {
+		// it turns half-open on the next request
+		s = stateHalfOpen
+	}
+	return breakerSnapshot{State: s.String(), Failures: b.failures, Since: b.since}
+}
@@ -0,0 +1,202 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/circuitbreaker/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/gateway/proxy/condition"
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+func TestConsecutiveBreaker(t *testing.T) {
+	now := time.Now()
+	var changes []string
+	b := newConsecutiveBreaker(&consecutiveOptions{failures: 3, openDuration: time.Second, halfOpenRequests: 2}, func(from, to state) {
+		changes = append(changes, from.String()+"->"+to.String())
+	})
+	b.now = func() time.Time { return now }
+
+	fail := func() {
+		tk, ok := b.allow()
+		if !ok {
+			t.Fatalf("expected allowed in %s", b.state)
+		}
+		b.mark(tk, false)
+	}
+	fail()
+	fail()
+	tk, _ := b.allow()
+	b.mark(tk, true)
+	// the success resets the consecutive failures
+	fail()
+	fail()
+	if b.state != stateClosed {
+		t.Fatalf("expected closed, got %s", b.state)
+	}
+	fail()
+	if b.state != stateOpen {
+		t.Fatalf("expected open, got %s", b.state)
+	}
+	if _, ok := b.allow(); ok || b.available() {
+		t.Fatalf("expected denied in open state")
+	}
+
+	now = now.Add(time.Second)
+	if !b.available() {
+		t.Fatalf("expected available after the open duration")
+	}
+	p1, ok1 := b.allow()
+	p2, ok2 := b.allow()
+	if _, ok := b.allow(); !ok1 || !ok2 || ok || !p1.probe || !p2.probe {
+		t.Fatalf("expected 2 probes in half-open state")
+	}
+	b.release(p2)
+	p3, ok := b.allow()
+	if !ok {
+		t.Fatalf("expected the released probe to be acquired")
+	}
+	b.mark(p1, true)
+	if b.state != stateHalfOpen {
+		t.Fatalf("expected half-open, got %s", b.state)
+	}
+	b.mark(p3, false)
+	if b.state != stateOpen {
+		t.Fatalf("expected open on the failed probe, got %s", b.state)
+	}
+	// the late result of the previous state is ignored
+	b.mark(p2, true)
+
+	now = now.Add(time.Second)
+	p1, _ = b.allow()
+	p2, _ = b.allow()
+	b.mark(p1, true)
+	b.mark(p2, true)
+	if b.state != stateClosed {
+		t.Fatalf("expected closed on the succeeded probes, got %s", b.state)
+	}
+	expected := "closed->open,open->half_open,half_open->open,open->half_open,half_open->closed"
+	if got := strings.Join(changes, ","); got != expected {
+		t.Fatalf("expected changes %s, got %s", expected, got)
+	}
+}
+
+type fakeNode struct {
+	selector.Node
+	addr string
+}
+
+func (n *fakeNode) Address() string { return n.addr }
+
+// fakeSelector picks the first node passing the filters like the client.
+func fakeSelector(nodes []selector.Node, status map[string]int) http.RoundTripper {
+	return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		reqOpts, _ := middleware.FromRequestContext(req.Context())
+		candidates := append([]selector.Node{}, nodes...)
+		filters, _ := middleware.SelectorFiltersFromContext(req.Context())
+		for _, f := range filters {
+			candidates = f(req.Context(), candidates)
+		}
+		if len(candidates) == 0 {
+			return nil, selector.ErrNoAvailable
+		}
+		addr := candidates[0].Address()
+		reqOpts.Backends = append(reqOpts.Backends, addr)
+		return &http.Response{StatusCode: status[addr], Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+}
+
+func TestNodeScope(t *testing.T) {
+	nodes := []selector.Node{&fakeNode{addr: "127.0.0.1:8001"}, &fakeNode{addr: "127.0.0.1:8002"}}
+	status := map[string]int{"127.0.0.1:8001": 503, "127.0.0.1:8002": 200}
+	g := newBreakerGroup(v1.Scope_NODE, newConsecutiveOptions(&v1.ConsecutiveFailures{Failures: 2}))
+	assert, err := parseAssertions(`200-499`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onBreak := middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 599, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+	rt := g.middleware(onBreak, assert)(fakeSelector(nodes, status))
+	do := func() (int, []string) {
+		reqOpts := middleware.NewRequestOptions(&config.Endpoint{Path: "/foo"})
+		req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+		req = req.WithContext(middleware.NewRequestContext(context.Background(), reqOpts))
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, reqOpts.Backends
+	}
+	for i := 0; i < 2; i++ {
+		if code, backends := do(); code != 503 || backends[0] != "127.0.0.1:8001" {
+			t.Fatalf("expected the first node, got %d %v", code, backends)
+		}
+	}
+	if code, backends := do(); code != 200 || backends[0] != "127.0.0.1:8002" {
+		t.Fatalf("expected the broken node to be skipped, got %d %v", code, backends)
+	}
+	// the breakers are created on the failures
+	if snapshot := g.snapshot(); len(snapshot) != 1 || snapshot["127.0.0.1:8001"].State != "open" {
+		t.Fatalf("unexpected states: %+v", snapshot)
+	}
+
+	status["127.0.0.1:8002"] = 500
+	do()
+	do()
+	if code, _ := do(); code != 599 {
+		t.Fatalf("expected the on break handler when all nodes are broken, got %d", code)
+	}
+}
+
+func TestNodeBreakersPruned(t *testing.T) {
+	g := newBreakerGroup(v1.Scope_NODE, newConsecutiveOptions(&v1.ConsecutiveFailures{Failures: 2}))
+	g.ttl = 100 * time.Millisecond
+	g.get("127.0.0.1:8001", true)
+	g.get("127.0.0.1:8002", true)
+	time.Sleep(60 * time.Millisecond)
+	// the node still in use is kept
+	g.get("127.0.0.1:8001", false)
+	time.Sleep(60 * time.Millisecond)
+	g.get("127.0.0.1:8003", false)
+	if snapshot := g.snapshot(); len(snapshot) != 1 || snapshot["127.0.0.1:8001"].State != "closed" {
+		t.Fatalf("expected the breaker of the removed node to be pruned, got %+v", snapshot)
+	}
+}
+
+func TestEndpointScope(t *testing.T) {
+	g := newBreakerGroup(v1.Scope_ENDPOINT, newConsecutiveOptions(&v1.ConsecutiveFailures{Failures: 1}))
+	assert, err := parseAssertions(`200`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent int
+	next := middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		sent++
+		return nil, errors.New("connection refused")
+	})
+	onBreak := middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 599, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+	rt := g.middleware(onBreak, assert)(next)
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	if _, err := rt.RoundTrip(req); err == nil {
+		t.Fatal("expected the error")
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != 599 || sent != 1 {
+		t.Fatalf("expected the request to be denied, got %v %v %d", resp, err, sent)
+	}
+}
+
+func parseAssertions(codes string) ([]condition.Condition, error) {
+	return condition.ParseConditon(&config.Condition{Condition: &config.Condition_ByStatusCode{ByStatusCode: codes}})
+}
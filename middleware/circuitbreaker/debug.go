@@ -0,0 +1,101 @@
+package circuitbreaker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/proxy/debug"
+)
+
+const _maxStateEvents = 128
+
+func init() {
+	debug.Register("circuitbreaker", debugger{})
+}
+
+var registry = struct {
+	lock   sync.Mutex
+	groups map[*breakerGroup]struct{}
+	events []*stateEvent
+}{
+	groups: make(map[*breakerGroup]struct{}),
+}
+
+type stateEvent struct {
+	Time     time.Time `json:"time"`
+	Endpoint string    `json:"endpoint"`
+	Node     string    `json:"node,omitempty"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+}
+
+func registerGroup(g *breakerGroup) {
+	registry.lock.Lock()
+	registry.groups[g] = struct{}{}
+	registry.lock.Unlock()
+}
+
+func unregisterGroup(g *breakerGroup) {
+	registry.lock.Lock()
+	delete(registry.groups, g)
+	registry.lock.Unlock()
+}
+
+// recordEvent keeps the recent state changes.
+func recordEvent(e *stateEvent) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	if len(registry.events) >= _maxStateEvents {
+		registry.events = append(registry.events[:0], registry.events[1:]...)
+	}
+	registry.events = append(registry.events, e)
+}
+
+func endpointName(e *config.Endpoint) string {
+	return strings.TrimSpace(e.Method + " " + e.Path)
+}
+
+type debugger struct{}
+
+type inspectGroup struct {
+	Endpoint string                     `json:"endpoint"`
+	Scope    string                     `json:"scope"`
+	Breakers map[string]breakerSnapshot `json:"breakers"`
+}
+
+type inspectBreakers struct {
+	Groups []*inspectGroup `json:"groups"`
+	Events []*stateEvent   `json:"events"`
+}
+
+// DebugHandler serves the states of the consecutive breakers and the recent state changes:
+//
+//	GET /debug/circuitbreaker
+func (debugger) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		registry.lock.Lock()
+		groups := make([]*breakerGroup, 0, len(registry.groups))
+		for g := range registry.groups {
+			groups = append(groups, g)
+		}
+		events := append([]*stateEvent{}, registry.events...)
+		registry.lock.Unlock()
+
+		out := &inspectBreakers{Groups: make([]*inspectGroup, 0, len(groups)), Events: events}
+		for _, g := range groups {
+			ig := &inspectGroup{Scope: g.scope.String(), Breakers: g.snapshot()}
+			if e := g.endpoint.Load(); e != nil {
+				ig.Endpoint = endpointName(e)
+			}
+			out.Groups = append(out.Groups, ig)
+		}
+		sort.Slice(out.Groups, func(i, j int) bool { return out.Groups[i].Endpoint < out.Groups[j].Endpoint })
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(out)
+	})
+}
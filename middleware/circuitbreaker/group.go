@@ -0,0 +1,235 @@
+package circuitbreaker
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/circuitbreaker/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/gateway/proxy/condition"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+var _nodeBreakerTTL = time.Minute
+
+// breakerGroup holds the consecutive breakers of an endpoint, keyed by the node address in the node scope.
+type breakerGroup struct {
+	opts     *consecutiveOptions
+	scope    v1.Scope
+	endpoint atomic.Pointer[config.Endpoint]
+	// the node breakers not seen longer than ttl are removed
+	ttl       time.Duration
+	lastSweep atomic.Int64
+
+	lock     sync.RWMutex
+	breakers map[string]*consecutiveBreaker
+}
+
+func newBreakerGroup(scope v1.Scope, opts *consecutiveOptions) *breakerGroup {
+	g := &breakerGroup{
+		opts:     opts,
+		scope:    scope,
+		ttl:      max(_nodeBreakerTTL, opts.openDuration),
+		breakers: make(map[string]*consecutiveBreaker),
+	}
+	g.lastSweep.Store(time.Now().UnixNano())
+	return g
+}
+
+// get returns the breaker of the key, the missing breaker is closed without any failures.
+func (g *breakerGroup) get(key string, create bool) *consecutiveBreaker {
+	now := time.Now().UnixNano()
+	if g.scope == v1.Scope_NODE {
+		g.sweep(now)
+	}
+	g.lock.RLock()
+	b, ok := g.breakers[key]
+	g.lock.RUnlock()
+	if ok || !create {
+		if ok {
+			b.lastSeen.Store(now)
+		}
+		return b
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if b, ok := g.breakers[key]; ok {
+		b.lastSeen.Store(now)
+		return b
+	}
+	b = newConsecutiveBreaker(g.opts, func(from, to state) {
+		g.stateChanged(key, from, to)
+	})
+	b.lastSeen.Store(now)
+	g.breakers[key] = b
+	return b
+}
+
+// sweep removes the breakers of the nodes which are not candidates anymore,
+// the nodes in use are seen by the filter on every request.
+func (g *breakerGroup) sweep(now int64) {
+	last := g.lastSweep.Load()
+	if now-last < int64(g.ttl) || !g.lastSweep.CompareAndSwap(last, now) {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for key, b := range g.breakers {
+		if now-b.lastSeen.Load() > int64(g.ttl) {
+			delete(g.breakers, key)
+		}
+	}
+}
+
+func (g *breakerGroup) stateChanged(key string, from, to state) {
+	e := g.endpoint.Load()
+	if e == nil {
+		return
+	}
+	labels := middleware.NewMetricsLabels(e)
+	_metricStateChangesTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), key, to.String()).Inc()
+	log.Warnf("Circuit breaker of %s %s %s changed from %s to %s", e.Method, e.Path, key, from, to)
+	recordEvent(&stateEvent{
+		Time:     time.Now(),
+		Endpoint: endpointName(e),
+		Node:     key,
+		From:     from.String(),
+		To:       to.String(),
+	})
+}
+
+func (g *breakerGroup) snapshot() map[string]breakerSnapshot {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	out := make(map[string]breakerSnapshot, len(g.breakers))
+	for key, b := range g.breakers {
+		out[key] = b.snapshot()
+	}
+	return out
+}
+
+func (g *breakerGroup) Close() error {
+	unregisterGroup(g)
+	return nil
+}
+
+func (g *breakerGroup) middleware(onBreakHandler http.RoundTripper, assertCondtions []condition.Condition) middleware.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if e, ok := middleware.EndpointFromContext(req.Context()); ok && g.endpoint.Load() == nil {
+				g.endpoint.Store(e)
+			}
+			if g.scope == v1.Scope_NODE {
+				return g.roundTripNode(next, onBreakHandler, assertCondtions, req)
+			}
+			b := g.get("", true)
+			t, ok := b.allow()
+			if !ok {
+				deniedRequestIncr(req)
+				return onBreakHandler.RoundTrip(req)
+			}
+			resp, err := next.RoundTrip(req)
+			b.mark(t, isSuccess(assertCondtions, req, resp, err))
+			return resp, err
+		})
+	}
+}
+
+// roundTripNode skips the nodes with the open breakers, the request is handled by the
+// on break handler if all the nodes are broken.
+func (g *breakerGroup) roundTripNode(next, onBreakHandler http.RoundTripper, assertCondtions []condition.Condition, req *http.Request) (*http.Response, error) {
+	reqOpts, ok := middleware.FromRequestContext(req.Context())
+	if !ok {
+		return next.RoundTrip(req)
+	}
+	a := &nodeAttempt{group: g, tickets: make(map[string]ticket)}
+	// the filter is kept by the following attempts, it's disabled once the attempt finished
+	middleware.WithSelectorFitler(req.Context(), a.filter)
+	sent := len(reqOpts.Backends)
+	resp, err := next.RoundTrip(req)
+	var addr string
+	if len(reqOpts.Backends) > sent {
+		addr = reqOpts.Backends[len(reqOpts.Backends)-1]
+	}
+	t, denied := a.finish(addr)
+	if addr == "" {
+		if denied && err != nil {
+			deniedRequestIncr(req)
+			return onBreakHandler.RoundTrip(req)
+		}
+		return resp, err
+	}
+	success := isSuccess(assertCondtions, req, resp, err)
+	if b := g.get(addr, !success); b != nil {
+		b.mark(t, success)
+	}
+	return resp, err
+}
+
+func isSuccess(assertCondtions []condition.Condition, req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return isSuccessError(assertCondtions, req, err)
+	}
+	return isSuccessResponse(assertCondtions, resp)
+}
+
+// nodeAttempt acquires the permissions of the candidates and releases the ones not selected.
+type nodeAttempt struct {
+	group   *breakerGroup
+	lock    sync.Mutex
+	done    bool
+	denied  bool
+	tickets map[string]ticket
+}
+
+func (a *nodeAttempt) filter(_ context.Context, nodes []selector.Node) []selector.Node {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.done {
+		return nodes
+	}
+	a.releaseLocked("")
+	allowed := make([]selector.Node, 0, len(nodes))
+	for _, n := range nodes {
+		b := a.group.get(n.Address(), false)
+		if b == nil {
+			allowed = append(allowed, n)
+			continue
+		}
+		t, ok := b.allow()
+		if !ok {
+			continue
+		}
+		a.tickets[n.Address()] = t
+		allowed = append(allowed, n)
+	}
+	a.denied = len(nodes) > 0 && len(allowed) == 0
+	return allowed
+}
+
+// releaseLocked releases the permissions except the selected node.
+func (a *nodeAttempt) releaseLocked(selected string) {
+	for addr, t := range a.tickets {
+		if addr == selected {
+			continue
+		}
+		if b := a.group.get(addr, false); b != nil {
+			b.release(t)
+		}
+		delete(a.tickets, addr)
+	}
+}
+
+// finish disables the filter and returns the permission of the selected node.
+func (a *nodeAttempt) finish(selected string) (ticket, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.done = true
+	a.releaseLocked(selected)
+	return a.tickets[selected], a.denied
+}
@@ -0,0 +1,231 @@
+package extauthz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/extauthz/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	_defaultTimeout        = time.Second
+	_defaultAllowedHeaders = []string{"Authorization"}
+	_maxResponseBytes      = int64(64 << 10)
+)
+
+var clientBuildContext atomic.Pointer[client.BuildContext]
+
+var (
+	_metricAuthzTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_ext_authz_total",
+		Help:      "The total number of external authorization checks",
+	}, []string{"protocol", "method", "path", "service", "basePath", "result"})
+)
+
+const (
+	resultAllowed = "allowed"
+	resultDenied  = "denied"
+	resultError   = "error"
+)
+
+func init() {
+	clientBuildContext.Store(client.EmptyBuildContext())
+	prometheus.MustRegister(_metricAuthzTotal)
+}
+
+func Init(buildContext *client.BuildContext, clientFactory client.Factory) {
+	SetBuildContext(buildContext)
+	middleware.RegisterV2("extauthz", New(clientFactory))
+}
+
+func SetBuildContext(buildContext *client.BuildContext) {
+	clientBuildContext.Store(buildContext)
+}
+
+// checkResult is the decision of the authorization service.
+type checkResult struct {
+	allowed    bool
+	statusCode int
+	// the headers injected into the upstream request if allowed,
+	// or returned to the client if denied.
+	header        http.Header
+	removeHeaders []string
+	body          []byte
+}
+
+type checker func(ctx context.Context, client http.RoundTripper, req *http.Request) (*checkResult, error)
+
+type options struct {
+	*v1.ExtAuthz
+	allowedHeaders []string
+}
+
+// selectHeaders returns the request headers sent to the authorization service.
+func (o *options) selectHeaders(header http.Header) http.Header {
+	out := make(http.Header, len(o.allowedHeaders))
+	for _, key := range o.allowedHeaders {
+		if values := header.Values(key); len(values) > 0 {
+			out[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	return out
+}
+
+func newHTTPChecker(options *options) checker {
+	return func(ctx context.Context, client http.RoundTripper, req *http.Request) (*checkResult, error) {
+		checkURL := &url.URL{
+			Scheme:   "http",
+			Host:     "authz",
+			Path:     options.PathPrefix + req.URL.Path,
+			RawQuery: req.URL.RawQuery,
+		}
+		checkReq, err := http.NewRequestWithContext(ctx, req.Method, checkURL.String(), http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		checkReq.Header = options.selectHeaders(req.Header)
+		resp, err := client.RoundTrip(checkReq)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, _maxResponseBytes))
+		if err != nil {
+			return nil, err
+		}
+		result := &checkResult{
+			allowed:    resp.StatusCode >= 200 && resp.StatusCode < 300,
+			statusCode: resp.StatusCode,
+			header:     make(http.Header),
+		}
+		if !result.allowed {
+			result.header = resp.Header
+			result.body = body
+			return result, nil
+		}
+		for _, key := range options.AllowedUpstreamHeaders {
+			if values := resp.Header.Values(key); len(values) > 0 {
+				result.header[http.CanonicalHeaderKey(key)] = values
+			}
+		}
+		return result, nil
+	}
+}
+
+func authzIncr(req *http.Request, result string) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricAuthzTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), result).Inc()
+	}
+}
+
+func (o *options) deniedResponse(req *http.Request, result *checkResult) *http.Response {
+	if endpoint, ok := middleware.EndpointFromContext(req.Context()); ok && endpoint.Protocol == config.Protocol_GRPC {
+		return middleware.NewErrorResponse(req, result.statusCode, http.StatusText(result.statusCode))
+	}
+	header := make(http.Header)
+	if len(o.AllowedClientHeaders) == 0 {
+		for key, values := range result.header {
+			header[key] = values
+		}
+		header.Del("Content-Length")
+		header.Del("Transfer-Encoding")
+		header.Del("Connection")
+	} else {
+		for _, key := range o.AllowedClientHeaders {
+			if values := result.header.Values(key); len(values) > 0 {
+				header[http.CanonicalHeaderKey(key)] = values
+			}
+		}
+	}
+	return &http.Response{
+		Status:        http.StatusText(result.statusCode),
+		StatusCode:    result.statusCode,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(result.body)),
+		ContentLength: int64(len(result.body)),
+		Request:       req,
+	}
+}
+
+// New returns the external authorization middleware factory, the authorization service
+// is created by the client factory, so that it could be discovered by the registry.
+func New(factory client.Factory) middleware.FactoryV2 {
+	return func(c *config.Middleware) (middleware.MiddlewareV2, error) {
+		o := &options{ExtAuthz: &v1.ExtAuthz{}}
+		if c.Options != nil {
+			if err := anypb.UnmarshalTo(c.Options, o.ExtAuthz, proto.UnmarshalOptions{Merge: true}); err != nil {
+				return nil, err
+			}
+		}
+		if o.Service == nil || len(o.Service.Backends) == 0 {
+			return nil, errors.New("ext_authz service is required")
+		}
+		o.allowedHeaders = o.AllowedHeaders
+		if len(o.allowedHeaders) == 0 {
+			o.allowedHeaders = _defaultAllowedHeaders
+		}
+		timeout := _defaultTimeout
+		if o.Timeout != nil && o.Timeout.AsDuration() > 0 {
+			timeout = o.Timeout.AsDuration()
+		}
+		statusOnError := http.StatusForbidden
+		if o.StatusOnError > 0 {
+			statusOnError = int(o.StatusOnError)
+		}
+		check := newHTTPChecker(o)
+		if o.Service.Protocol == config.Protocol_GRPC {
+			check = newGRPCChecker(o)
+		}
+		authzClient, err := factory(clientBuildContext.Load(), o.Service)
+		if err != nil {
+			return nil, err
+		}
+		return middleware.NewWithCloser(func(next http.RoundTripper) http.RoundTripper {
+			return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				ctx, cancel := context.WithTimeout(req.Context(), timeout)
+				defer cancel()
+				// the authorization call has its own request options, so that it doesn't
+				// affect the node selection and metrics of the request.
+				ctx = middleware.NewRequestContext(ctx, middleware.NewRequestOptions(o.Service))
+				result, err := check(ctx, authzClient, req)
+				if err != nil {
+					authzIncr(req, resultError)
+					if o.FailureModeAllow {
+						log.Warnf("ext_authz failed on %s, allow the request in failure mode: %v", req.URL.Path, err)
+						return next.RoundTrip(req)
+					}
+					log.Errorf("ext_authz failed on %s: %v", req.URL.Path, err)
+					return middleware.NewErrorResponse(req, statusOnError, "external authorization failed"), nil
+				}
+				if !result.allowed {
+					authzIncr(req, resultDenied)
+					return o.deniedResponse(req, result), nil
+				}
+				authzIncr(req, resultAllowed)
+				for _, key := range result.removeHeaders {
+					req.Header.Del(key)
+				}
+				for key, values := range result.header {
+					req.Header[key] = values
+				}
+				return next.RoundTrip(req)
+			})
+		}, authzClient), nil
+	}
+}
@@ -0,0 +1,218 @@
+package extauthz
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/extauthz/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestHTTPAuthz(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/authz/api/users" || r.Method != http.MethodPost || r.URL.RawQuery != "id=1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Cookie") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Header.Get("Authorization") {
+		case "Bearer alice":
+			w.Header().Set("X-User-Id", "alice")
+			w.Header().Set("X-Internal", "secret")
+			w.WriteHeader(http.StatusOK)
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("denied"))
+		}
+	}))
+	defer srv.Close()
+
+	service := &config.Endpoint{
+		Protocol: config.Protocol_HTTP,
+		Backends: []*config.Backend{{Target: strings.TrimPrefix(srv.URL, "http://")}},
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-User-Id": req.Header.Values("X-User-Id"), "X-Internal": req.Header.Values("X-Internal")},
+			Body:       http.NoBody,
+		}, nil
+	})
+	tests := []struct {
+		name          string
+		options       *v1.ExtAuthz
+		authorization string
+		statusCode    int
+		header        http.Header
+		body          string
+	}{
+		{
+			name:          "allowed",
+			authorization: "Bearer alice",
+			statusCode:    http.StatusOK,
+			header:        http.Header{"X-User-Id": {"alice"}},
+		},
+		{
+			name:          "denied",
+			authorization: "Bearer bob",
+			statusCode:    http.StatusUnauthorized,
+			header:        http.Header{"Www-Authenticate": {"Bearer"}},
+			body:          "denied",
+		},
+		{
+			name:          "failure mode deny",
+			authorization: "slow",
+			statusCode:    http.StatusForbidden,
+			body:          "external authorization failed",
+		},
+		{
+			name:          "failure mode allow",
+			options:       &v1.ExtAuthz{FailureModeAllow: true},
+			authorization: "slow",
+			statusCode:    http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := &v1.ExtAuthz{}
+			if test.options != nil {
+				options = test.options
+			}
+			options.Service = service
+			options.PathPrefix = "/authz"
+			options.Timeout = durationpb.New(100 * time.Millisecond)
+			options.AllowedUpstreamHeaders = []string{"X-User-Id"}
+			v, err := anypb.New(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := New(client.NewFactory(nil))(&config.Middleware{Options: v})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer m.Close()
+
+			req, _ := http.NewRequest(http.MethodPost, "http://example.com/api/users?id=1", strings.NewReader("body"))
+			req.Header.Set("Authorization", test.authorization)
+			req.Header.Set("Cookie", "session=1")
+			req.Header.Set("X-User-Id", "spoofed")
+			resp, err := m.Process(next).RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != test.statusCode {
+				t.Fatalf("expected status code %d, got %d", test.statusCode, resp.StatusCode)
+			}
+			for key := range test.header {
+				if got := resp.Header.Get(key); got != test.header.Get(key) {
+					t.Errorf("expected header %s %q, got %q", key, test.header.Get(key), got)
+				}
+			}
+			if got := resp.Header.Get("X-Internal"); got != "" {
+				t.Errorf("unexpected header X-Internal: %q", got)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != test.body {
+				t.Errorf("expected body %q, got %q", test.body, body)
+			}
+		})
+	}
+}
+
+func TestGRPCCheckMessage(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/api/users?id=1", nil)
+	frame := encodeCheckRequest(req, http.Header{"Authorization": {"Bearer alice"}})
+	if size := binary.BigEndian.Uint32(frame[1:5]); int(size) != len(frame)-5 {
+		t.Fatalf("unexpected frame size: %d", size)
+	}
+	var path string
+	var headers []string
+	// CheckRequest.attributes.request.http
+	err := rangeFields(frame[5:], func(_ protowire.Number, attributes []byte, _ uint64) error {
+		return rangeFields(attributes, func(_ protowire.Number, request []byte, _ uint64) error {
+			return rangeFields(request, func(_ protowire.Number, httpReq []byte, _ uint64) error {
+				return rangeFields(httpReq, func(num protowire.Number, v []byte, _ uint64) error {
+					switch num {
+					case 3:
+						return rangeFields(v, func(_ protowire.Number, v []byte, _ uint64) error {
+							headers = append(headers, string(v))
+							return nil
+						})
+					case 4:
+						path = string(v)
+					}
+					return nil
+				})
+			})
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/api/users?id=1" {
+		t.Errorf("unexpected path: %q", path)
+	}
+	if strings.Join(headers, ":") != "authorization:Bearer alice" {
+		t.Errorf("unexpected headers: %q", headers)
+	}
+
+	header := func(key, value string, appendValue bool) []byte {
+		var hv []byte
+		hv = appendString(hv, 1, key)
+		hv = appendString(hv, 2, value)
+		opt := appendMessage(nil, 1, hv)
+		if appendValue {
+			opt = appendMessage(opt, 2, protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 1))
+		}
+		return opt
+	}
+	encode := func(msg []byte) []byte {
+		frame := make([]byte, 5, 5+len(msg))
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+		return append(frame, msg...)
+	}
+
+	var ok []byte
+	ok = appendMessage(ok, 2, header("x-user-id", "alice", false))
+	ok = appendMessage(ok, 2, header("x-role", "admin", true))
+	ok = appendString(ok, 5, "authorization")
+	result, err := decodeCheckResponse(encode(appendMessage(nil, 3, ok)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.allowed || result.header.Get("X-User-Id") != "alice" || result.header.Get("X-Role") != "admin" ||
+		len(result.removeHeaders) != 1 || result.removeHeaders[0] != "authorization" {
+		t.Errorf("unexpected ok result: %+v", result)
+	}
+
+	var denied []byte
+	denied = appendMessage(denied, 1, protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 401))
+	denied = appendString(denied, 3, "denied")
+	var msg []byte
+	msg = appendMessage(msg, 1, protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 16))
+	msg = appendMessage(msg, 2, denied)
+	result, err = decodeCheckResponse(encode(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.allowed || result.statusCode != http.StatusUnauthorized || string(result.body) != "denied" {
+		t.Errorf("unexpected denied result: %+v", result)
+	}
+}
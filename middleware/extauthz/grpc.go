@@ -0,0 +1,230 @@
+package extauthz
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// see https://github.com/envoyproxy/envoy/blob/main/api/envoy/service/auth/v3/external_auth.proto
+const _grpcCheckPath = "/envoy.service.auth.v3.Authorization/Check"
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// encodeCheckRequest encodes the envoy.service.auth.v3.CheckRequest in a gRPC frame.
+func encodeCheckRequest(req *http.Request, headers http.Header) []byte {
+	var httpReq []byte
+	httpReq = appendString(httpReq, 2, req.Method)
+	for key, values := range headers {
+		var entry []byte
+		entry = appendString(entry, 1, strings.ToLower(key))
+		entry = appendString(entry, 2, strings.Join(values, ","))
+		httpReq = appendMessage(httpReq, 3, entry)
+	}
+	httpReq = appendString(httpReq, 4, req.URL.RequestURI())
+	httpReq = appendString(httpReq, 5, req.Host)
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	httpReq = appendString(httpReq, 6, scheme)
+	httpReq = appendString(httpReq, 7, req.URL.RawQuery)
+	httpReq = appendString(httpReq, 10, req.Proto)
+
+	// AttributeContext.request.http
+	request := appendMessage(nil, 2, httpReq)
+	attributes := appendMessage(nil, 4, request)
+	msg := appendMessage(nil, 1, attributes)
+
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+// rangeFields calls fn on each length-delimited or varint field of the message.
+func rangeFields(msg []byte, fn func(num protowire.Number, v []byte, x uint64) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(msg)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if err := fn(num, v, 0); err != nil {
+				return err
+			}
+			msg = msg[n:]
+		case protowire.VarintType:
+			x, n := protowire.ConsumeVarint(msg)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if err := fn(num, nil, x); err != nil {
+				return err
+			}
+			msg = msg[n:]
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			msg = msg[n:]
+		}
+	}
+	return nil
+}
+
+// decodeHeaderValueOption decodes the envoy.config.core.v3.HeaderValueOption into the header.
+func decodeHeaderValueOption(msg []byte, header http.Header) error {
+	var key, value string
+	var appendValue bool
+	err := rangeFields(msg, func(num protowire.Number, v []byte, _ uint64) error {
+		switch num {
+		case 1:
+			return rangeFields(v, func(num protowire.Number, v []byte, _ uint64) error {
+				switch num {
+				case 1:
+					key = string(v)
+				case 2:
+					value = string(v)
+				}
+				return nil
+			})
+		case 2:
+			// google.protobuf.BoolValue
+			return rangeFields(v, func(num protowire.Number, _ []byte, x uint64) error {
+				if num == 1 {
+					appendValue = x != 0
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil || key == "" {
+		return err
+	}
+	if appendValue {
+		header.Add(key, value)
+	} else {
+		header.Set(key, value)
+	}
+	return nil
+}
+
+// decodeCheckResponse decodes the envoy.service.auth.v3.CheckResponse in a gRPC frame.
+func decodeCheckResponse(in []byte) (*checkResult, error) {
+	if len(in) < 5 {
+		return nil, fmt.Errorf("invalid grpc frame: %d bytes", len(in))
+	}
+	size := binary.BigEndian.Uint32(in[1:5])
+	msg := in[5:]
+	if uint32(len(msg)) < size {
+		return nil, fmt.Errorf("truncated grpc message: %d < %d", len(msg), size)
+	}
+	result := &checkResult{
+		allowed:    true,
+		statusCode: http.StatusForbidden,
+		header:     make(http.Header),
+	}
+	err := rangeFields(msg[:size], func(num protowire.Number, v []byte, _ uint64) error {
+		switch num {
+		case 1:
+			// google.rpc.Status
+			return rangeFields(v, func(num protowire.Number, _ []byte, x uint64) error {
+				if num == 1 && x != 0 {
+					result.allowed = false
+				}
+				return nil
+			})
+		case 2:
+			// DeniedHttpResponse
+			return rangeFields(v, func(num protowire.Number, v []byte, _ uint64) error {
+				switch num {
+				case 1:
+					return rangeFields(v, func(num protowire.Number, _ []byte, x uint64) error {
+						if num == 1 && x > 0 {
+							result.statusCode = int(x)
+						}
+						return nil
+					})
+				case 2:
+					return decodeHeaderValueOption(v, result.header)
+				case 3:
+					result.body = v
+				}
+				return nil
+			})
+		case 3:
+			// OkHttpResponse
+			return rangeFields(v, func(num protowire.Number, v []byte, _ uint64) error {
+				switch num {
+				case 2:
+					return decodeHeaderValueOption(v, result.header)
+				case 5:
+					result.removeHeaders = append(result.removeHeaders, string(v))
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func newGRPCChecker(options *options) checker {
+	return func(ctx context.Context, client http.RoundTripper, req *http.Request) (*checkResult, error) {
+		body := encodeCheckRequest(req, options.selectHeaders(req.Header))
+		checkReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://authz"+_grpcCheckPath, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		checkReq.Header.Set("Content-Type", "application/grpc")
+		checkReq.Header.Set("TE", "trailers")
+		resp, err := client.RoundTrip(checkReq)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		reply, err := io.ReadAll(io.LimitReader(resp.Body, _maxResponseBytes))
+		if err != nil {
+			return nil, err
+		}
+		grpcStatus := resp.Trailer.Get("Grpc-Status")
+		if grpcStatus == "" {
+			grpcStatus = resp.Header.Get("Grpc-Status")
+		}
+		if grpcStatus != "0" {
+			return nil, fmt.Errorf("unexpected grpc status: %q: %s", grpcStatus, resp.Trailer.Get("Grpc-Message"))
+		}
+		return decodeCheckResponse(reply)
+	}
+}
@@ -0,0 +1,237 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/feature"
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/fault/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	faultDelay    = "delay"
+	faultAbort    = "abort"
+	faultResponse = "response"
+)
+
+const _abortMessage = "fault filter abort"
+
+// faultFeature switches all the fault middlewares at runtime, the faults are disabled by default.
+var faultFeature = feature.MustRegister("gw:Fault", false)
+
+var _metricFaultTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_fault_total",
+	Help:      "The total number of requests with the injected faults",
+}, []string{"protocol", "method", "path", "service", "basePath", "fault"})
+
+func init() {
+	prometheus.MustRegister(_metricFaultTotal)
+	middleware.Register("fault", Middleware)
+}
+
+func faultIncr(req *http.Request, fault string) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricFaultTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), fault).Inc()
+	}
+}
+
+// hit reports whether the request is chosen by the percentage, nil means all the requests.
+func hit(percentage *float64) bool {
+	return percentage == nil || *percentage >= 100 || rand.Float64()*100 < *percentage
+}
+
+func validPercentage(percentage *float64) error {
+	if percentage != nil && (*percentage < 0 || *percentage > 100) {
+		return fmt.Errorf("invalid fault percentage: %v", *percentage)
+	}
+	return nil
+}
+
+func matchHeaders(headers []*v1.Header, req *http.Request) bool {
+	for _, h := range headers {
+		values, ok := req.Header[http.CanonicalHeaderKey(h.Name)]
+		if !ok {
+			return false
+		}
+		if h.Value == "" {
+			continue
+		}
+		matched := false
+		for _, v := range values {
+			if v == h.Value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func parseGRPCCode(in string) (codes.Code, error) {
+	var code codes.Code
+	if _, err := strconv.ParseUint(in, 10, 32); err == nil {
+		err := code.UnmarshalJSON([]byte(in))
+		return code, err
+	}
+	err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(in))))
+	return code, err
+}
+
+// abortResponse replies with the http status, or the trailers-only response of the grpc code.
+func abortResponse(req *http.Request, abort *v1.Abort, grpcCode codes.Code) *http.Response {
+	if _, ok := abort.Error.(*v1.Abort_HttpStatus); ok {
+		return middleware.NewErrorResponse(req, int(abort.GetHttpStatus()), _abortMessage)
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/grpc")
+	header.Set("Grpc-Status", strconv.Itoa(int(grpcCode)))
+	header.Set("Grpc-Message", _abortMessage)
+	return &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       http.NoBody,
+		Request:    req,
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// faultBody cuts the body after the limit and throttles the reads by the rate.
+type faultBody struct {
+	io.ReadCloser
+	ctx       context.Context
+	remaining int64
+	rate      int64
+}
+
+func (b *faultBody) Read(p []byte) (int, error) {
+	if b.remaining == 0 {
+		return 0, io.EOF
+	}
+	if b.remaining > 0 && int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	if b.rate > 0 {
+		// read the chunk of 100ms at most to send the body smoothly
+		if chunk := max(b.rate/10, 1); int64(len(p)) > chunk {
+			p = p[:chunk]
+		}
+	}
+	n, err := b.ReadCloser.Read(p)
+	if b.remaining > 0 {
+		b.remaining -= int64(n)
+	}
+	if b.rate > 0 && n > 0 {
+		if serr := sleepContext(b.ctx, time.Duration(n)*time.Second/time.Duration(b.rate)); serr != nil {
+			return n, serr
+		}
+	}
+	return n, err
+}
+
+// Middleware injects the delays, aborts and the response faults into the matched requests.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Fault{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.Delay == nil && options.Abort == nil && options.Response == nil {
+		return nil, errors.New("fault middleware requires at least one of delay, abort and response")
+	}
+	var percentages []*float64
+	if options.Delay != nil {
+		percentages = append(percentages, options.Delay.Percentage)
+	}
+	if options.Abort != nil {
+		percentages = append(percentages, options.Abort.Percentage)
+	}
+	if options.Response != nil {
+		percentages = append(percentages, options.Response.Percentage)
+	}
+	for _, p := range percentages {
+		if err := validPercentage(p); err != nil {
+			return nil, err
+		}
+	}
+	var grpcCode codes.Code
+	if abort := options.Abort; abort != nil {
+		switch e := abort.Error.(type) {
+		case *v1.Abort_HttpStatus:
+			if e.HttpStatus < 200 || e.HttpStatus > 599 {
+				return nil, fmt.Errorf("invalid fault abort http status: %d", e.HttpStatus)
+			}
+		case *v1.Abort_GrpcCode:
+			code, err := parseGRPCCode(e.GrpcCode)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fault abort grpc code: %s", e.GrpcCode)
+			}
+			grpcCode = code
+		default:
+			return nil, errors.New("fault abort requires http_status or grpc_code")
+		}
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !faultFeature.Enabled() || !matchHeaders(options.Headers, req) {
+				return next.RoundTrip(req)
+			}
+			if delay := options.Delay; delay != nil && hit(delay.Percentage) {
+				faultIncr(req, faultDelay)
+				if err := sleepContext(req.Context(), delay.FixedDelay.AsDuration()); err != nil {
+					return nil, err
+				}
+			}
+			if abort := options.Abort; abort != nil && hit(abort.Percentage) {
+				faultIncr(req, faultAbort)
+				return abortResponse(req, abort, grpcCode), nil
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if rf := options.Response; rf != nil && (rf.TruncateBytes > 0 || rf.BytesPerSecond > 0) && hit(rf.Percentage) {
+				faultIncr(req, faultResponse)
+				remaining := int64(-1)
+				if rf.TruncateBytes > 0 {
+					remaining = rf.TruncateBytes
+					// the truncated body is shorter than the declared length
+					resp.Header.Del("Content-Length")
+					resp.ContentLength = -1
+				}
+				resp.Body = &faultBody{ReadCloser: resp.Body, ctx: req.Context(), remaining: remaining, rate: rf.BytesPerSecond}
+			}
+			return resp, nil
+		})
+	}, nil
+}
@@ -0,0 +1,154 @@
+package fault
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/feature"
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/fault/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newFault(t *testing.T, options *v1.Fault) (http.RoundTripper, *int) {
+	t.Helper()
+	opts, err := anypb.New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: opts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent int
+	return m(middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{
+			StatusCode:    200,
+			Header:        http.Header{"Content-Length": {"11"}},
+			Body:          io.NopCloser(strings.NewReader("hello world")),
+			ContentLength: 11,
+		}, nil
+	})), &sent
+}
+
+func enableFault(t *testing.T, enabled bool) {
+	t.Helper()
+	if err := feature.SetEnabled("gw:Fault", enabled); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { feature.SetEnabled("gw:Fault", false) })
+}
+
+func TestAbort(t *testing.T) {
+	rt, sent := newFault(t, &v1.Fault{
+		Headers: []*v1.Header{{Name: "X-Fault"}},
+		Abort:   &v1.Abort{Error: &v1.Abort_HttpStatus{HttpStatus: 503}},
+	})
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set("X-Fault", "1")
+	// disabled by the feature
+	resp, _ := rt.RoundTrip(req)
+	if resp.StatusCode != 200 || *sent != 1 {
+		t.Fatalf("expected no faults when the feature is disabled, got %d", resp.StatusCode)
+	}
+
+	enableFault(t, true)
+	resp, _ = rt.RoundTrip(req)
+	if resp.StatusCode != 503 || *sent != 1 {
+		t.Fatalf("expected aborted, got %d", resp.StatusCode)
+	}
+	resp, _ = rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/foo", nil))
+	if resp.StatusCode != 200 || *sent != 2 {
+		t.Fatalf("expected the request without the header to be sent, got %d", resp.StatusCode)
+	}
+
+	// the grpc status of the grpc endpoints
+	reqOpts := middleware.NewRequestOptions(&config.Endpoint{Protocol: config.Protocol_GRPC})
+	resp, _ = rt.RoundTrip(req.WithContext(middleware.NewRequestContext(req.Context(), reqOpts)))
+	if resp.StatusCode != 200 || resp.Header.Get("Grpc-Status") != "14" {
+		t.Fatalf("expected the grpc unavailable, got %d %v", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestAbortGRPCCode(t *testing.T) {
+	enableFault(t, true)
+	for code, expected := range map[string]string{"RESOURCE_EXHAUSTED": "8", "unavailable": "14", "4": "4"} {
+		rt, _ := newFault(t, &v1.Fault{Abort: &v1.Abort{Error: &v1.Abort_GrpcCode{GrpcCode: code}}})
+		resp, _ := rt.RoundTrip(httptest.NewRequest(http.MethodPost, "/helloworld.Greeter/SayHello", nil))
+		if got := resp.Header.Get("Grpc-Status"); got != expected {
+			t.Errorf("%s: expected grpc status %s, got %s", code, expected, got)
+		}
+	}
+	opts, _ := anypb.New(&v1.Fault{Abort: &v1.Abort{Error: &v1.Abort_GrpcCode{GrpcCode: "NOT_A_CODE"}}})
+	if _, err := Middleware(&config.Middleware{Options: opts}); err == nil {
+		t.Errorf("expected error on the unknown grpc code")
+	}
+}
+
+func TestDelay(t *testing.T) {
+	enableFault(t, true)
+	rt, sent := newFault(t, &v1.Fault{Delay: &v1.Delay{FixedDelay: durationpb.New(50 * time.Millisecond)}})
+	start := time.Now()
+	if _, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/foo", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || *sent != 1 {
+		t.Fatalf("expected the request to be delayed, got %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil).WithContext(ctx)
+	if _, err := rt.RoundTrip(req); err != context.DeadlineExceeded || *sent != 1 {
+		t.Fatalf("expected the deadline exceeded, got %v", err)
+	}
+}
+
+func TestResponseFault(t *testing.T) {
+	enableFault(t, true)
+	rt, _ := newFault(t, &v1.Fault{Response: &v1.ResponseFault{TruncateBytes: 5}})
+	resp, _ := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/foo", nil))
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "hello" || resp.ContentLength != -1 || resp.Header.Get("Content-Length") != "" {
+		t.Fatalf("expected the truncated body of unknown length, got %q %d", body, resp.ContentLength)
+	}
+
+	rt, _ = newFault(t, &v1.Fault{Response: &v1.ResponseFault{BytesPerSecond: 110}})
+	resp, _ = rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/foo", nil))
+	start := time.Now()
+	body, _ = io.ReadAll(resp.Body)
+	if elapsed := time.Since(start); string(body) != "hello world" || elapsed < 90*time.Millisecond {
+		t.Fatalf("expected the slow body, got %q in %s", body, elapsed)
+	}
+}
+
+func TestZeroPercentage(t *testing.T) {
+	enableFault(t, true)
+	rt, sent := newFault(t, &v1.Fault{
+		Delay:    &v1.Delay{FixedDelay: durationpb.New(time.Hour), Percentage: proto.Float64(0)},
+		Abort:    &v1.Abort{Error: &v1.Abort_HttpStatus{HttpStatus: 503}, Percentage: proto.Float64(0)},
+		Response: &v1.ResponseFault{TruncateBytes: 5, Percentage: proto.Float64(0)},
+	})
+	for i := 0; i < 100; i++ {
+		resp, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != 200 || string(body) != "hello world" {
+			t.Fatalf("expected no faults of 0 percentage, got %d %q", resp.StatusCode, body)
+		}
+	}
+	if *sent != 100 {
+		t.Fatalf("expected all the requests to be sent, got %d", *sent)
+	}
+}
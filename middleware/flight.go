@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"io"
+	"sync"
+)
+
+// Call is the in-flight call of a key in the CallGroup.
+type Call[T any] struct {
+	done  chan struct{}
+	value T
+}
+
+// Done is closed once the leader ended the call.
+func (c *Call[T]) Done() <-chan struct{} { return c.done }
+
+// Value returns the result of the call, it's valid after Done is closed.
+func (c *Call[T]) Value() T { return c.value }
+
+// CallGroup coalesces the concurrent calls of the same key.
+type CallGroup[T any] struct {
+	lock  sync.Mutex
+	calls map[string]*Call[T]
+}
+
+// NewCallGroup new a call group.
+func NewCallGroup[T any]() *CallGroup[T] {
+	return &CallGroup[T]{calls: make(map[string]*Call[T])}
+}
+
+// Begin returns the call of the key, leader is true if the caller should make the call and end it.
+func (g *CallGroup[T]) Begin(key string) (c *Call[T], leader bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if c, ok := g.calls[key]; ok {
+		return c, false
+	}
+	c = &Call[T]{done: make(chan struct{})}
+	g.calls[key] = c
+	return c, true
+}
+
+// End publishes the result of the call to the waiters.
+func (g *CallGroup[T]) End(key string, c *Call[T], value T) {
+	g.lock.Lock()
+	delete(g.calls, key)
+	g.lock.Unlock()
+	c.value = value
+	close(c.done)
+}
+
+// MultiReadCloser reads from the Reader and closes the Closer,
+// e.g. the peeked bytes followed by the rest of the body.
+type MultiReadCloser struct {
+	io.Reader
+	io.Closer
+}
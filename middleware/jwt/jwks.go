@@ -0,0 +1,327 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/jwt/v1"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var (
+	_defaultJWKSRefreshInterval = 5 * time.Minute
+	// the min interval to refresh the JWKS on unknown key id
+	_minJWKSRefreshInterval = 10 * time.Second
+	// the first interval to retry the failed initial load, it's doubled up to the refresh interval
+	_jwksRetryInterval = time.Second
+	// the max duration the requests of unknown key id wait for the refresh
+	_jwksRefreshWait  = 500 * time.Millisecond
+	_jwksFetchTimeout = 10 * time.Second
+	_jwksMaxBytes     = int64(1 << 20)
+)
+
+var _jwksClient = &http.Client{Timeout: _jwksFetchTimeout}
+
+// jwk is a parsed JSON web key.
+type jwk struct {
+	kid string
+	alg string
+	kty string
+	// *rsa.PublicKey, *ecdsa.PublicKey or []byte
+	key any
+}
+
+type rawJWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+func decodeBigInt(in string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(in)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func parseJWK(raw *rawJWK) (*jwk, error) {
+	out := &jwk{kid: raw.Kid, alg: raw.Alg, kty: raw.Kty}
+	switch raw.Kty {
+	case "RSA":
+		n, err := decodeBigInt(raw.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(raw.E)
+		if err != nil {
+			return nil, err
+		}
+		if n.Sign() == 0 || !e.IsInt64() || e.Int64() <= 1 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid rsa public key")
+		}
+		out.key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		var curve elliptic.Curve
+		switch raw.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %q", raw.Crv)
+		}
+		x, err := decodeBigInt(raw.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(raw.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid ec public key")
+		}
+		out.key = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	case "oct":
+		k, err := base64.RawURLEncoding.DecodeString(raw.K)
+		if err != nil {
+			return nil, err
+		}
+		if len(k) == 0 {
+			return nil, errors.New("empty symmetric key")
+		}
+		out.key = k
+	default:
+		return nil, fmt.Errorf("unsupported key type: %q", raw.Kty)
+	}
+	return out, nil
+}
+
+// parseJWKS parses the JSON web key set, the keys not for signature are ignored.
+func parseJWKS(data []byte) ([]*jwk, error) {
+	set := struct {
+		Keys []*rawJWK `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := make([]*jwk, 0, len(set.Keys))
+	for _, raw := range set.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(raw)
+		if err != nil {
+			log.Warnf("skip invalid jwk %q: %v", raw.Kid, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no valid key in jwks")
+	}
+	return keys, nil
+}
+
+func fetchJWKS(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := _jwksClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code on fetching jwks: %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, _jwksMaxBytes))
+}
+
+// keyProvider caches the JWKS and refreshes it periodically for key rotation.
+type keyProvider struct {
+	lock   sync.RWMutex
+	keys   []*jwk
+	source func(context.Context) ([]byte, error)
+	name   string
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	refreshLock sync.Mutex
+	lastRefresh time.Time
+	// closed once the refresh in progress is done
+	refreshing chan struct{}
+}
+
+func newKeyProvider(options *v1.Jwt) (*keyProvider, error) {
+	p := &keyProvider{}
+	switch source := options.JwksSource.(type) {
+	case *v1.Jwt_Jwks:
+		keys, err := parseJWKS([]byte(source.Jwks))
+		if err != nil {
+			return nil, err
+		}
+		p.keys = keys
+		return p, nil
+	case *v1.Jwt_JwksFile:
+		p.name = source.JwksFile
+		p.source = func(context.Context) ([]byte, error) {
+			return os.ReadFile(source.JwksFile)
+		}
+	case *v1.Jwt_JwksUri:
+		p.name = source.JwksUri
+		p.source = func(ctx context.Context) ([]byte, error) {
+			return fetchJWKS(ctx, source.JwksUri)
+		}
+	default:
+		return nil, errors.New("jwks source is required")
+	}
+	interval := _defaultJWKSRefreshInterval
+	if options.JwksRefreshInterval != nil && options.JwksRefreshInterval.AsDuration() > 0 {
+		interval = options.JwksRefreshInterval.AsDuration()
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	// the keys are loaded in background, the requests wait for the initial load like the refreshes
+	go p.run(p.refresh(true), min(_jwksRetryInterval, interval), interval)
+	return p, nil
+}
+
+// run retries the initial load with backoff until the keys are loaded, the JWKS may be
+// temporarily unavailable, then refreshes the keys every interval.
+func (p *keyProvider) run(initial <-chan struct{}, backoff, interval time.Duration) {
+	<-initial
+	for !p.loaded() {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-p.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, interval)
+		<-p.refresh(true)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		<-p.refresh(true)
+	}
+}
+
+// refresh starts refreshing the keys in background unless a refresh is in progress, the refresh
+// is skipped if it's not forced and the keys were refreshed recently. The returned channel is
+// closed once the refresh is done, it's nil if the refresh is skipped.
+func (p *keyProvider) refresh(force bool) <-chan struct{} {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+	if p.refreshing != nil {
+		return p.refreshing
+	}
+	if !force && time.Since(p.lastRefresh) < _minJWKSRefreshInterval {
+		return nil
+	}
+	p.lastRefresh = time.Now()
+	done := make(chan struct{})
+	p.refreshing = done
+	go func() {
+		if err := p.load(p.ctx); err != nil {
+			log.Errorf("failed to refresh jwks from %s: %v", p.name, err)
+		}
+		p.refreshLock.Lock()
+		p.refreshing = nil
+		p.refreshLock.Unlock()
+		close(done)
+	}()
+	return done
+}
+
+func (p *keyProvider) load(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, _jwksFetchTimeout)
+	defer cancel()
+	data, err := p.source(ctx)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	p.keys = keys
+	p.lock.Unlock()
+	return nil
+}
+
+func (p *keyProvider) loaded() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return len(p.keys) > 0
+}
+
+func (p *keyProvider) find(kid string) []*jwk {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var out []*jwk
+	for _, key := range p.keys {
+		if kid == "" || key.kid == kid {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// lookup returns the candidate keys of the key id, the keys are refreshed at most once
+// per _minJWKSRefreshInterval if the key id is unknown, the request waits for the refresh
+// no longer than _jwksRefreshWait.
+func (p *keyProvider) lookup(ctx context.Context, kid string) []*jwk {
+	keys := p.find(kid)
+	if len(keys) > 0 || p.source == nil {
+		return keys
+	}
+	if done := p.refresh(false); done != nil {
+		timer := time.NewTimer(_jwksRefreshWait)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	return p.find(kid)
+}
+
+func (p *keyProvider) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/jwt/v1"
+)
+
+func octJWK(kid string) map[string]string {
+	return map[string]string{"kty": "oct", "kid": kid, "k": b64([]byte(kid))}
+}
+
+func TestJwksRotation(t *testing.T) {
+	var rotated atomic.Bool
+	var fetched atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		if rotated.Load() {
+			w.Write([]byte(jwks(octJWK("new"))))
+			return
+		}
+		w.Write([]byte(jwks(octJWK("old"))))
+	}))
+	defer srv.Close()
+
+	p, err := newKeyProvider(&v1.Jwt{JwksSource: &v1.Jwt_JwksUri{JwksUri: srv.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	ctx := context.Background()
+	// the known key id waits for the initial load
+	if keys := p.lookup(ctx, "old"); len(keys) != 1 {
+		t.Fatalf("expected the key to be loaded, got %d keys", len(keys))
+	}
+	rotated.Store(true)
+	// the keys were just loaded, the unknown key id is rejected without refreshing
+	if keys := p.lookup(ctx, "new"); len(keys) != 0 {
+		t.Fatalf("expected no keys, got %d keys", len(keys))
+	}
+	if n := fetched.Load(); n != 1 {
+		t.Fatalf("expected jwks to be fetched once, got %d", n)
+	}
+	defer func(d time.Duration) { _minJWKSRefreshInterval = d }(_minJWKSRefreshInterval)
+	_minJWKSRefreshInterval = 0
+	if keys := p.lookup(ctx, "new"); len(keys) != 1 {
+		t.Fatalf("expected the rotated key to be loaded, got %d keys", len(keys))
+	}
+	if keys := p.find("old"); len(keys) != 0 {
+		t.Fatalf("expected the old key to be removed, got %d keys", len(keys))
+	}
+}
+
+func TestJwksRefreshWait(t *testing.T) {
+	var (
+		slow    atomic.Bool
+		fetched atomic.Int32
+	)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		if slow.Load() {
+			<-release
+		}
+		w.Write([]byte(jwks(octJWK("old"))))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	p, err := newKeyProvider(&v1.Jwt{JwksSource: &v1.Jwt_JwksUri{JwksUri: srv.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	ctx := context.Background()
+	if keys := p.lookup(ctx, "old"); len(keys) != 1 {
+		t.Fatalf("expected the key to be loaded, got %d keys", len(keys))
+	}
+	defer func(d, wait time.Duration) { _minJWKSRefreshInterval, _jwksRefreshWait = d, wait }(_minJWKSRefreshInterval, _jwksRefreshWait)
+	_minJWKSRefreshInterval, _jwksRefreshWait = 0, 50*time.Millisecond
+	slow.Store(true)
+	// the lookups of unknown key id share the refresh in progress and stop waiting for it
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if keys := p.lookup(ctx, "new"); len(keys) != 0 {
+				t.Errorf("expected no keys, got %d keys", len(keys))
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the lookups not to wait for the slow refresh, got %s", elapsed)
+	}
+	if n := fetched.Load(); n != 2 {
+		t.Fatalf("expected a single refresh in progress, got %d fetches", n)
+	}
+}
+
+func TestJwksRetry(t *testing.T) {
+	var fetched atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fetched.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(jwks(octJWK("kid"))))
+	}))
+	defer srv.Close()
+
+	defer func(d time.Duration) { _jwksRetryInterval = d }(_jwksRetryInterval)
+	_jwksRetryInterval = 10 * time.Millisecond
+	// the failed initial load is retried long before the refresh interval
+	p, err := newKeyProvider(&v1.Jwt{JwksSource: &v1.Jwt_JwksUri{JwksUri: srv.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for !p.loaded() {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the jwks to be loaded, got %d fetches", fetched.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := fetched.Load(); n != 3 {
+		t.Fatalf("expected jwks to be fetched 3 times, got %d", n)
+	}
+}
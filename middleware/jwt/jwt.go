@@ -0,0 +1,383 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/jwt/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+var (
+	_defaultAlgorithms  = []string{"RS256", "ES256"}
+	_defaultTokenHeader = "Authorization"
+	_defaultClockSkew   = 60 * time.Second
+)
+
+var (
+	errTokenMissing      = errors.New("token is missing")
+	errTokenMalformed    = errors.New("token is malformed")
+	errTokenUnverifiable = errors.New("token signature is invalid")
+	errTokenExpired      = errors.New("token is expired")
+	errTokenNotValidYet  = errors.New("token is not valid yet")
+	errInvalidIssuer     = errors.New("token issuer is invalid")
+	errInvalidAudience   = errors.New("token audience is invalid")
+)
+
+type algorithm struct {
+	kty  string
+	hash crypto.Hash
+	// the byte size of r and s in ECDSA signatures
+	keySize int
+}
+
+var _algorithms = map[string]algorithm{
+	"RS256": {kty: "RSA", hash: crypto.SHA256},
+	"RS384": {kty: "RSA", hash: crypto.SHA384},
+	"RS512": {kty: "RSA", hash: crypto.SHA512},
+	"ES256": {kty: "EC", hash: crypto.SHA256, keySize: 32},
+	"ES384": {kty: "EC", hash: crypto.SHA384, keySize: 48},
+	"ES512": {kty: "EC", hash: crypto.SHA512, keySize: 66},
+	"HS256": {kty: "oct", hash: crypto.SHA256},
+	"HS384": {kty: "oct", hash: crypto.SHA384},
+	"HS512": {kty: "oct", hash: crypto.SHA512},
+}
+
+func init() {
+	middleware.RegisterV2("jwt", Middleware)
+}
+
+type claimsKey struct{}
+
+// Claims is the claims of the verified token.
+type Claims map[string]any
+
+// Get returns the claim by name, nested claims are separated by dots.
+func (c Claims) Get(name string) (any, bool) {
+	var cur any = map[string]any(c)
+	for _, key := range strings.Split(name, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// NewClaimsContext returns a new context with the claims.
+func NewClaimsContext(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the verified token from context.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type verifier struct {
+	options    *v1.Jwt
+	algorithms map[string]algorithm
+	keys       *keyProvider
+	clockSkew  time.Duration
+}
+
+func (v *verifier) verifySignature(alg algorithm, key *jwk, signed, signature []byte) bool {
+	h := alg.hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+	switch k := key.key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, alg.hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		if len(signature) != 2*alg.keySize || (k.Curve.Params().BitSize+7)/8 != alg.keySize {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:alg.keySize])
+		s := new(big.Int).SetBytes(signature[alg.keySize:])
+		return ecdsa.Verify(k, digest, r, s)
+	case []byte:
+		mac := hmac.New(alg.hash.New, k)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	}
+	return false
+}
+
+// parse verifies the token signature and returns the claims.
+func (v *verifier) parse(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errTokenMalformed
+	}
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errTokenMalformed
+	}
+	h := &header{}
+	if err := json.Unmarshal(headerData, h); err != nil {
+		return nil, errTokenMalformed
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errTokenMalformed
+	}
+	alg, ok := v.algorithms[h.Alg]
+	if !ok {
+		return nil, fmt.Errorf("token algorithm %q is not allowed", h.Alg)
+	}
+	signed := []byte(token[:len(parts[0])+1+len(parts[1])])
+	verified := false
+	for _, key := range v.keys.lookup(ctx, h.Kid) {
+		if key.kty != alg.kty || (key.alg != "" && key.alg != h.Alg) {
+			continue
+		}
+		if v.verifySignature(alg, key, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errTokenUnverifiable
+	}
+	claimsData, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errTokenMalformed
+	}
+	claims := Claims{}
+	decoder := json.NewDecoder(bytes.NewReader(claimsData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, errTokenMalformed
+	}
+	return claims, nil
+}
+
+func numericDate(claims Claims, name string) (time.Time, bool, error) {
+	v, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false, errTokenMalformed
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false, errTokenMalformed
+	}
+	return time.Unix(int64(f), 0), true, nil
+}
+
+func (v *verifier) validate(claims Claims, now time.Time) error {
+	exp, ok, err := numericDate(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if ok && now.After(exp.Add(v.clockSkew)) {
+		return errTokenExpired
+	}
+	nbf, ok, err := numericDate(claims, "nbf")
+	if err != nil {
+		return err
+	}
+	if ok && now.Add(v.clockSkew).Before(nbf) {
+		return errTokenNotValidYet
+	}
+	if v.options.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.options.Issuer {
+			return errInvalidIssuer
+		}
+	}
+	if len(v.options.Audiences) > 0 && !matchValues(claims["aud"], v.options.Audiences) {
+		return errInvalidAudience
+	}
+	return nil
+}
+
+// matchValues reports whether the string value or any element of the array value is one of the values.
+func matchValues(claim any, values []string) bool {
+	var candidates []any
+	switch c := claim.(type) {
+	case []any:
+		candidates = c
+	default:
+		candidates = []any{c}
+	}
+	for _, candidate := range candidates {
+		s := claimString(candidate)
+		for _, v := range values {
+			if s == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkRequiredClaims(claims Claims, required []*v1.RequiredClaim) error {
+	for _, r := range required {
+		claim, ok := claims.Get(r.Name)
+		if !ok {
+			return fmt.Errorf("claim %q is required", r.Name)
+		}
+		if len(r.Values) > 0 && !matchValues(claim, r.Values) {
+			return fmt.Errorf("claim %q is not allowed", r.Name)
+		}
+	}
+	return nil
+}
+
+// claimString formats the claim as a header value.
+func claimString(claim any) string {
+	switch c := claim.(type) {
+	case string:
+		return c
+	case json.Number:
+		return c.String()
+	case bool:
+		return strconv.FormatBool(c)
+	case []any:
+		out := make([]string, 0, len(c))
+		for _, e := range c {
+			out = append(out, claimString(e))
+		}
+		return strings.Join(out, ",")
+	case nil:
+		return ""
+	}
+	b, _ := json.Marshal(claim)
+	return string(b)
+}
+
+func (v *verifier) extractToken(req *http.Request) string {
+	if value := req.Header.Get(v.options.TokenHeader); value != "" {
+		if len(value) > 7 && strings.EqualFold(value[:7], "Bearer ") {
+			return strings.TrimSpace(value[7:])
+		}
+		if !strings.EqualFold(v.options.TokenHeader, _defaultTokenHeader) {
+			return value
+		}
+		return ""
+	}
+	if v.options.TokenQuery != "" {
+		return req.URL.Query().Get(v.options.TokenQuery)
+	}
+	return ""
+}
+
+func (v *verifier) stripToken(req *http.Request) {
+	req.Header.Del(v.options.TokenHeader)
+	if v.options.TokenQuery != "" {
+		query := req.URL.Query()
+		if query.Has(v.options.TokenQuery) {
+			query.Del(v.options.TokenQuery)
+			req.URL.RawQuery = query.Encode()
+		}
+	}
+}
+
+func unauthorized(req *http.Request, err error) *http.Response {
+	resp := middleware.NewErrorResponse(req, http.StatusUnauthorized, err.Error())
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Header.Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+	return resp
+}
+
+// Middleware verifies the JWT of the request and forwards the claims to the upstream.
+func Middleware(c *config.Middleware) (middleware.MiddlewareV2, error) {
+	options := &v1.Jwt{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.TokenHeader == "" {
+		options.TokenHeader = _defaultTokenHeader
+	}
+	names := options.Algorithms
+	if len(names) == 0 {
+		names = _defaultAlgorithms
+	}
+	algorithms := make(map[string]algorithm, len(names))
+	for _, name := range names {
+		alg, ok := _algorithms[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported jwt algorithm: %q", name)
+		}
+		algorithms[name] = alg
+	}
+	clockSkew := _defaultClockSkew
+	if options.ClockSkew != nil {
+		clockSkew = options.ClockSkew.AsDuration()
+	}
+	keys, err := newKeyProvider(options)
+	if err != nil {
+		return nil, err
+	}
+	v := &verifier{
+		options:    options,
+		algorithms: algorithms,
+		keys:       keys,
+		clockSkew:  clockSkew,
+	}
+	return middleware.NewWithCloser(func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the claim headers are only trusted from the gateway
+			for _, h := range options.ClaimsToHeaders {
+				req.Header.Del(h.Header)
+			}
+			token := v.extractToken(req)
+			if token == "" {
+				return unauthorized(req, errTokenMissing), nil
+			}
+			claims, err := v.parse(req.Context(), token)
+			if err != nil {
+				return unauthorized(req, err), nil
+			}
+			if err := v.validate(claims, time.Now()); err != nil {
+				return unauthorized(req, err), nil
+			}
+			if err := checkRequiredClaims(claims, options.RequiredClaims); err != nil {
+				return middleware.NewErrorResponse(req, http.StatusForbidden, err.Error()), nil
+			}
+			for _, h := range options.ClaimsToHeaders {
+				if claim, ok := claims.Get(h.Claim); ok {
+					req.Header.Set(h.Header, claimString(claim))
+				}
+			}
+			if options.StripToken {
+				v.stripToken(req)
+			}
+			return next.RoundTrip(req.WithContext(NewClaimsContext(req.Context(), claims)))
+		})
+	}, keys), nil
+}
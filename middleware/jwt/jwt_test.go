@@ -0,0 +1,211 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/jwt/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func b64(in []byte) string {
+	return base64.RawURLEncoding.EncodeToString(in)
+}
+
+func sign(t *testing.T, alg, kid string, key any, claims map[string]any) string {
+	h, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	c, _ := json.Marshal(claims)
+	signed := b64(h) + "." + b64(c)
+	hash := _algorithms[alg].hash
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := _algorithms[alg].keySize
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+	return signed + "." + b64(signature)
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   b64(key.X.FillBytes(make([]byte, 32))),
+		"y":   b64(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func jwks(keys ...map[string]string) string {
+	b, _ := json.Marshal(map[string]any{"keys": keys})
+	return string(b)
+}
+
+type result struct {
+	statusCode int
+	header     http.Header
+}
+
+func roundTrip(t *testing.T, m middleware.MiddlewareV2, req *http.Request) result {
+	var upstream http.Header
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if _, ok := ClaimsFromContext(req.Context()); !ok {
+			t.Error("expected claims in context")
+		}
+		upstream = req.Header.Clone()
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	resp, err := m.Process(next).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upstream == nil {
+		upstream = resp.Header
+	}
+	return result{statusCode: resp.StatusCode, header: upstream}
+}
+
+func TestJwt(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+	options, err := anypb.New(&v1.Jwt{
+		Issuer:     "https://issuer.example.com",
+		Audiences:  []string{"gateway"},
+		Algorithms: []string{"RS256", "ES256", "HS256"},
+		JwksSource: &v1.Jwt_Jwks{Jwks: jwks(
+			rsaJWK("rsa", rsaKey),
+			ecJWK("ec", ecKey),
+			map[string]string{"kty": "oct", "kid": "hmac", "k": b64(secret)},
+		)},
+		RequiredClaims: []*v1.RequiredClaim{
+			{Name: "realm_access.roles", Values: []string{"admin"}},
+		},
+		ClaimsToHeaders: []*v1.ClaimToHeader{
+			{Claim: "sub", Header: "x-user-id"},
+			{Claim: "realm_access.roles", Header: "x-user-roles"},
+		},
+		StripToken: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	now := time.Now().Unix()
+	claims := func(overrides map[string]any) map[string]any {
+		out := map[string]any{
+			"iss":          "https://issuer.example.com",
+			"aud":          []string{"gateway", "other"},
+			"sub":          "alice",
+			"exp":          now + 60,
+			"realm_access": map[string]any{"roles": []string{"admin", "user"}},
+		}
+		for k, v := range overrides {
+			out[k] = v
+		}
+		return out
+	}
+	tests := []struct {
+		name       string
+		token      string
+		grpc       bool
+		statusCode int
+	}{
+		{name: "missing", statusCode: http.StatusUnauthorized},
+		// the grpc status of the trailers-only response
+		{name: "grpc", grpc: true, statusCode: http.StatusOK},
+		{name: "rs256", token: sign(t, "RS256", "rsa", rsaKey, claims(nil)), statusCode: http.StatusOK},
+		{name: "es256", token: sign(t, "ES256", "ec", ecKey, claims(nil)), statusCode: http.StatusOK},
+		{name: "hs256", token: sign(t, "HS256", "hmac", secret, claims(nil)), statusCode: http.StatusOK},
+		{name: "algorithm not allowed", token: sign(t, "RS384", "rsa", rsaKey, claims(nil)), statusCode: http.StatusUnauthorized},
+		{name: "key type mismatch", token: sign(t, "HS256", "rsa", secret, claims(nil)), statusCode: http.StatusUnauthorized},
+		{name: "expired", token: sign(t, "RS256", "rsa", rsaKey, claims(map[string]any{"exp": now - 120})), statusCode: http.StatusUnauthorized},
+		{name: "not valid yet", token: sign(t, "RS256", "rsa", rsaKey, claims(map[string]any{"nbf": now + 120})), statusCode: http.StatusUnauthorized},
+		{name: "issuer", token: sign(t, "RS256", "rsa", rsaKey, claims(map[string]any{"iss": "evil"})), statusCode: http.StatusUnauthorized},
+		{name: "audience", token: sign(t, "RS256", "rsa", rsaKey, claims(map[string]any{"aud": "other"})), statusCode: http.StatusUnauthorized},
+		{name: "required claim", token: sign(t, "RS256", "rsa", rsaKey, claims(map[string]any{"realm_access": map[string]any{"roles": []string{"user"}}})), statusCode: http.StatusForbidden},
+		{name: "tampered", token: sign(t, "RS256", "rsa", rsaKey, claims(nil)) + "x", statusCode: http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+			req.Header.Set("x-user-id", "spoofed")
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			if test.grpc {
+				req = req.WithContext(middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Protocol: config.Protocol_GRPC})))
+			}
+			res := roundTrip(t, m, req)
+			if res.statusCode != test.statusCode {
+				t.Fatalf("expected status code %d, got %d", test.statusCode, res.statusCode)
+			}
+			if test.grpc {
+				if res.header.Get("Grpc-Status") != "16" {
+					t.Fatalf("expected unauthenticated grpc status, got %q", res.header.Get("Grpc-Status"))
+				}
+				return
+			}
+			if res.statusCode != http.StatusOK {
+				return
+			}
+			if got := res.header.Get("x-user-id"); got != "alice" {
+				t.Errorf("expected x-user-id alice, got %q", got)
+			}
+			if got := res.header.Get("x-user-roles"); got != "admin,user" {
+				t.Errorf("expected x-user-roles admin,user, got %q", got)
+			}
+			if got := res.header.Get("Authorization"); got != "" {
+				t.Errorf("expected token to be stripped, got %q", got)
+			}
+		})
+	}
+}
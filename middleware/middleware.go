@@ -55,6 +55,13 @@ func NewWithCloser(process Middleware, closer io.Closer) MiddlewareV2 {
 	}
 }
 
+// NopCloser is the closer of the middlewares which hold no resources.
+var NopCloser io.Closer = nopCloser{}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
 var EmptyMiddleware = emptyMiddleware{}
 
 type emptyMiddleware struct{}
@@ -0,0 +1,252 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/mirror/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	_defaultTimeout        = 5 * time.Second
+	_defaultMaxBodySize    = int64(1 << 20)
+	_defaultMaxConcurrency = int64(100)
+	_defaultPercentage     = float64(100)
+)
+
+var clientBuildContext atomic.Pointer[client.BuildContext]
+
+const (
+	resultMatch    = "match"
+	resultMismatch = "mismatch"
+	resultError    = "error"
+	resultDropped  = "dropped"
+)
+
+var (
+	_metricMirrorTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_mirror_total",
+		Help:      "The total number of mirrored requests by the status comparison with the primary",
+	}, []string{"protocol", "method", "path", "service", "basePath", "result"})
+	_metricMirrorLatencyDiff = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_mirror_latency_diff_seconds",
+		Help:      "The latency of the shadow requests minus the latency of the primary requests",
+		Buckets:   []float64{-1, -0.5, -0.25, -0.1, -0.05, -0.025, -0.01, 0, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+)
+
+func init() {
+	clientBuildContext.Store(client.EmptyBuildContext())
+	prometheus.MustRegister(_metricMirrorTotal, _metricMirrorLatencyDiff)
+}
+
+func Init(buildContext *client.BuildContext, clientFactory client.Factory) {
+	SetBuildContext(buildContext)
+	middleware.RegisterV2("mirror", New(clientFactory))
+}
+
+func SetBuildContext(buildContext *client.BuildContext) {
+	clientBuildContext.Store(buildContext)
+}
+
+type result struct {
+	statusCode int
+	err        error
+	latency    time.Duration
+}
+
+type mirror struct {
+	*v1.Mirror
+	client         http.RoundTripper
+	percentage     float64
+	timeout        time.Duration
+	maxBodySize    int64
+	maxConcurrency int64
+	inflight       atomic.Int64
+}
+
+func (m *mirror) sampled() bool {
+	return m.percentage >= 100 || rand.Float64()*100 < m.percentage
+}
+
+// shadowBody returns the copy of the request body, ok is false if the body could not be mirrored.
+func (m *mirror) shadowBody(req *http.Request) (body []byte, ok bool) {
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0 {
+		return nil, true
+	}
+	if req.GetBody == nil || req.ContentLength > m.maxBodySize {
+		return nil, false
+	}
+	if endpoint, ok := middleware.EndpointFromContext(req.Context()); ok && endpoint.Streaming.GetEnabled() {
+		// the streaming body is forwarded as it arrives, it can't be read twice
+		return nil, false
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	defer rc.Close()
+	body, err = io.ReadAll(io.LimitReader(rc, m.maxBodySize+1))
+	if err != nil || int64(len(body)) > m.maxBodySize {
+		return nil, false
+	}
+	return body, true
+}
+
+// shadowRequest returns the copy of the request to the shadow endpoint,
+// it's not canceled with the primary request.
+func (m *mirror) shadowRequest(req *http.Request, body []byte) (*http.Request, *middleware.RequestOptions, context.CancelFunc) {
+	reqOpts := middleware.NewRequestOptions(m.Endpoint)
+	ctx := middleware.NewRequestContext(context.WithoutCancel(req.Context()), reqOpts)
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	shadow := req.Clone(ctx)
+	shadow.Host += m.HostSuffix
+	shadow.Body, shadow.GetBody, shadow.ContentLength = http.NoBody, nil, 0
+	if len(body) > 0 {
+		shadow.Body = io.NopCloser(bytes.NewReader(body))
+		shadow.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+		shadow.ContentLength = int64(len(body))
+	}
+	return shadow, reqOpts, cancel
+}
+
+func (m *mirror) send(shadow *http.Request, reqOpts *middleware.RequestOptions) result {
+	start := time.Now()
+	resp, err := m.client.RoundTrip(shadow)
+	if err != nil {
+		reqOpts.DoneFunc(shadow.Context(), selector.DoneInfo{Err: err})
+		return result{err: err, latency: time.Since(start)}
+	}
+	latency := time.Since(start)
+	_, err = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	reqOpts.DoneFunc(shadow.Context(), selector.DoneInfo{Err: err})
+	return result{statusCode: resp.StatusCode, latency: latency}
+}
+
+func observe(req *http.Request, primary, shadow result) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if !ok {
+		return
+	}
+	values := []string{labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath()}
+	outcome := resultMatch
+	switch {
+	case shadow.err != nil:
+		outcome = resultError
+	case primary.err != nil || primary.statusCode != shadow.statusCode:
+		outcome = resultMismatch
+	}
+	_metricMirrorTotal.WithLabelValues(append(values, outcome)...).Inc()
+	if shadow.err == nil && primary.err == nil {
+		_metricMirrorLatencyDiff.WithLabelValues(values...).Observe((shadow.latency - primary.latency).Seconds())
+	}
+}
+
+func droppedIncr(req *http.Request) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricMirrorTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), resultDropped).Inc()
+	}
+}
+
+func New(factory client.Factory) middleware.FactoryV2 {
+	return func(c *config.Middleware) (middleware.MiddlewareV2, error) {
+		options := &v1.Mirror{}
+		if c.Options != nil {
+			if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+				return nil, err
+			}
+		}
+		if options.Endpoint == nil {
+			return nil, errors.New("mirror endpoint is required")
+		}
+		m := &mirror{
+			Mirror:         options,
+			percentage:     _defaultPercentage,
+			timeout:        _defaultTimeout,
+			maxBodySize:    _defaultMaxBodySize,
+			maxConcurrency: _defaultMaxConcurrency,
+		}
+		if options.Percentage != nil {
+			if *options.Percentage < 0 || *options.Percentage > 100 {
+				return nil, fmt.Errorf("invalid mirror percentage: %v", *options.Percentage)
+			}
+			m.percentage = *options.Percentage
+		}
+		if options.Timeout != nil && options.Timeout.AsDuration() > 0 {
+			m.timeout = options.Timeout.AsDuration()
+		}
+		if options.MaxBodySize > 0 {
+			m.maxBodySize = options.MaxBodySize
+		}
+		if options.MaxConcurrency > 0 {
+			m.maxConcurrency = int64(options.MaxConcurrency)
+		}
+		shadowClient, err := factory(clientBuildContext.Load(), options.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		m.client = shadowClient
+		return middleware.NewWithCloser(func(next http.RoundTripper) http.RoundTripper {
+			return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				// the retries are not mirrored again
+				if middleware.IsRetryAttempt(req.Context()) || !m.sampled() {
+					return next.RoundTrip(req)
+				}
+				body, ok := m.shadowBody(req)
+				if !ok {
+					droppedIncr(req)
+					return next.RoundTrip(req)
+				}
+				if m.inflight.Add(1) > m.maxConcurrency {
+					m.inflight.Add(-1)
+					droppedIncr(req)
+					return next.RoundTrip(req)
+				}
+				shadow, reqOpts, cancel := m.shadowRequest(req, body)
+				primaryResult := make(chan result, 1)
+				go func() {
+					defer m.inflight.Add(-1)
+					defer cancel()
+					shadowResult := m.send(shadow, reqOpts)
+					if shadowResult.err != nil {
+						log.Debugf("mirror request to %s failed: %v", shadow.URL.Path, shadowResult.err)
+					}
+					observe(req, <-primaryResult, shadowResult)
+				}()
+
+				start := time.Now()
+				var primary result
+				defer func() { primaryResult <- primary }()
+				resp, err := next.RoundTrip(req)
+				primary.latency = time.Since(start)
+				if err != nil {
+					primary.err = err
+					return nil, err
+				}
+				primary.statusCode = resp.StatusCode
+				return resp, nil
+			})
+		}, shadowClient), nil
+	}
+}
@@ -0,0 +1,108 @@
+package mirror
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/mirror/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type shadowRequest struct {
+	host string
+	path string
+	body string
+}
+
+func TestMirror(t *testing.T) {
+	received := make(chan shadowRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// the slow shadow doesn't delay the primary
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusInternalServerError)
+		received <- shadowRequest{host: r.Host, path: r.URL.Path, body: string(body)}
+	}))
+	defer srv.Close()
+
+	options, err := anypb.New(&v1.Mirror{
+		Endpoint: &config.Endpoint{
+			Protocol: config.Protocol_HTTP,
+			Backends: []*config.Backend{{Target: strings.TrimPrefix(srv.URL, "http://")}},
+		},
+		HostSuffix: "-shadow",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := New(client.NewFactory(nil))(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != "payload" {
+			t.Errorf("expected the primary body to be intact, got %q", body)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	})
+	payload := []byte("payload")
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/api/users", bytes.NewReader(payload))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(payload)), nil }
+	ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Path: "/api/users", Protocol: config.Protocol_HTTP}))
+	start := time.Now()
+	resp, err := m.Process(next).RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || time.Since(start) > 100*time.Millisecond {
+		t.Fatalf("expected the primary response immediately, got %d after %s", resp.StatusCode, time.Since(start))
+	}
+	select {
+	case shadow := <-received:
+		if shadow.host != "example.com-shadow" || shadow.path != "/api/users" || shadow.body != "payload" {
+			t.Fatalf("unexpected shadow request: %+v", shadow)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the request to be mirrored")
+	}
+}
+
+func TestMirrorSkipped(t *testing.T) {
+	m := &mirror{Mirror: &v1.Mirror{}, maxBodySize: 4}
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/", strings.NewReader("payload"))
+	if _, ok := m.shadowBody(req); ok {
+		t.Fatal("expected the body without GetBody not to be mirrored")
+	}
+	req, _ = http.NewRequest(http.MethodPost, "http://example.com/", strings.NewReader("payload"))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("payload")), nil }
+	if _, ok := m.shadowBody(req); ok {
+		t.Fatal("expected the large body not to be mirrored")
+	}
+	m.percentage = 0.0001
+	var sampled int
+	for i := 0; i < 1000; i++ {
+		if m.sampled() {
+			sampled++
+		}
+	}
+	if sampled > 10 {
+		t.Fatalf("expected few requests to be sampled, got %d", sampled)
+	}
+	// 0 disables the mirroring
+	m.percentage = 0
+	for i := 0; i < 1000; i++ {
+		if m.sampled() {
+			t.Fatal("expected no requests to be sampled")
+		}
+	}
+}
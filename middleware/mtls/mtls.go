@@ -0,0 +1,123 @@
+package mtls
+
+import (
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/mtls/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	middleware.Register("mtls", Middleware)
+}
+
+// identity is the verified identity of the client certificate.
+type identity struct {
+	commonName string
+	subject    string
+	sans       []string
+	spiffeID   string
+}
+
+func newIdentity(cert *x509.Certificate) *identity {
+	id := &identity{
+		commonName: cert.Subject.CommonName,
+		subject:    cert.Subject.String(),
+	}
+	id.sans = append(id.sans, cert.DNSNames...)
+	id.sans = append(id.sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		id.sans = append(id.sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		id.sans = append(id.sans, uri.String())
+		if id.spiffeID == "" && uri.Scheme == "spiffe" {
+			id.spiffeID = uri.String()
+		}
+	}
+	return id
+}
+
+// names returns all the names of the identity to be authorized.
+func (id *identity) names() []string {
+	names := make([]string, 0, len(id.sans)+1)
+	if id.commonName != "" {
+		names = append(names, id.commonName)
+	}
+	names = append(names, id.sans...)
+	return names
+}
+
+func matchIdentity(pattern, name string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return pattern == name
+}
+
+func isAllowed(allowed []string, names []string) bool {
+	for _, pattern := range allowed {
+		for _, name := range names {
+			if matchIdentity(pattern, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifiedCertificate returns the client certificate which is verified by the server.
+func verifiedCertificate(req *http.Request) (*x509.Certificate, bool) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return req.TLS.VerifiedChains[0][0], true
+}
+
+// Middleware forwards and authorizes the identity of the verified client certificate.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Mtls{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	required := options.Required || len(options.AllowedIdentities) > 0
+	headers := []string{options.SubjectHeader, options.SanHeader, options.SpiffeIdHeader}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the identity headers are only trusted from the gateway
+			for _, h := range headers {
+				if h != "" {
+					req.Header.Del(h)
+				}
+			}
+			cert, ok := verifiedCertificate(req)
+			if !ok {
+				if required {
+					return middleware.NewErrorResponse(req, http.StatusUnauthorized, "client certificate required"), nil
+				}
+				return next.RoundTrip(req)
+			}
+			id := newIdentity(cert)
+			if len(options.AllowedIdentities) > 0 && !isAllowed(options.AllowedIdentities, id.names()) {
+				return middleware.NewErrorResponse(req, http.StatusForbidden, "client identity is not allowed"), nil
+			}
+			if options.SubjectHeader != "" {
+				req.Header.Set(options.SubjectHeader, id.subject)
+			}
+			if options.SanHeader != "" && len(id.sans) > 0 {
+				req.Header.Set(options.SanHeader, strings.Join(id.sans, ","))
+			}
+			if options.SpiffeIdHeader != "" && id.spiffeID != "" {
+				req.Header.Set(options.SpiffeIdHeader, id.spiffeID)
+			}
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
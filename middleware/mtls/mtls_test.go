@@ -0,0 +1,123 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/url"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/mtls/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func buildConfig(options *v1.Mtls) *config.Middleware {
+	v, err := anypb.New(options)
+	if err != nil {
+		panic(err)
+	}
+	return &config.Middleware{Options: v}
+}
+
+func newTLSState(commonName, spiffeID string) *tls.ConnectionState {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: commonName},
+		DNSNames: []string{commonName + ".internal"},
+	}
+	if spiffeID != "" {
+		u, _ := url.Parse(spiffeID)
+		cert.URIs = []*url.URL{u}
+	}
+	return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+}
+
+func TestMtls(t *testing.T) {
+	options := &v1.Mtls{
+		SubjectHeader:     "x-client-subject",
+		SanHeader:         "x-client-san",
+		SpiffeIdHeader:    "x-client-spiffe-id",
+		AllowedIdentities: []string{"spiffe://example.org/ns/prod/*", "admin"},
+	}
+	tests := []struct {
+		name       string
+		state      *tls.ConnectionState
+		statusCode int
+		subject    string
+		san        string
+		spiffeID   string
+	}{
+		{
+			name:       "no certificate",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "spiffe id allowed",
+			state:      newTLSState("foo", "spiffe://example.org/ns/prod/sa/foo"),
+			statusCode: http.StatusOK,
+			subject:    "CN=foo",
+			san:        "foo.internal,spiffe://example.org/ns/prod/sa/foo",
+			spiffeID:   "spiffe://example.org/ns/prod/sa/foo",
+		},
+		{
+			name:       "common name allowed",
+			state:      newTLSState("admin", ""),
+			statusCode: http.StatusOK,
+			subject:    "CN=admin",
+			san:        "admin.internal",
+		},
+		{
+			name:       "not allowed",
+			state:      newTLSState("bar", "spiffe://example.org/ns/dev/sa/bar"),
+			statusCode: http.StatusForbidden,
+		},
+	}
+	m, err := Middleware(buildConfig(options))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if got := req.Header.Get("x-client-subject"); got != test.subject {
+					t.Errorf("expected subject %q, got %q", test.subject, got)
+				}
+				if got := req.Header.Get("x-client-san"); got != test.san {
+					t.Errorf("expected san %q, got %q", test.san, got)
+				}
+				if got := req.Header.Get("x-client-spiffe-id"); got != test.spiffeID {
+					t.Errorf("expected spiffe id %q, got %q", test.spiffeID, got)
+				}
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			})
+			req, _ := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+			req.Header.Set("x-client-spiffe-id", "spiffe://example.org/ns/prod/sa/spoofed")
+			req.TLS = test.state
+			resp, err := m(next).RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != test.statusCode {
+				t.Errorf("expected status code %d, got %d", test.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestMtlsGRPC(t *testing.T) {
+	m, err := Middleware(buildConfig(&v1.Mtls{Required: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/helloworld.Greeter/SayHello", nil)
+	ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Protocol: config.Protocol_GRPC}))
+	resp, err := m(nil).RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Grpc-Status") != "16" {
+		t.Errorf("expected unauthenticated grpc status, got %d %q", resp.StatusCode, resp.Header.Get("Grpc-Status"))
+	}
+}
@@ -0,0 +1,212 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+)
+
+var _defaultPeriod = time.Second
+
+// decision is the result of taking a request from the quota.
+type decision struct {
+	allowed   bool
+	limit     int64
+	remaining int64
+	// the duration until the quota is fully restored
+	reset time.Duration
+	// the duration until the next request could be allowed
+	retryAfter time.Duration
+	// refund returns the allowed request to the quota
+	refund func()
+}
+
+// limiter counts the requests by key.
+type limiter interface {
+	take(ctx context.Context, key string, now time.Time) decision
+}
+
+// keyedStates keeps the states by key, the states idle longer than ttl are evicted.
+type keyedStates[T any] struct {
+	lock      sync.Mutex
+	states    map[string]*keyedState[T]
+	ttl       time.Duration
+	lastSweep time.Time
+}
+
+type keyedState[T any] struct {
+	state    T
+	lastSeen time.Time
+}
+
+func newKeyedStates[T any](ttl time.Duration) *keyedStates[T] {
+	return &keyedStates[T]{
+		states: make(map[string]*keyedState[T]),
+		ttl:    ttl,
+	}
+}
+
+// do calls fn with the state of the key under lock.
+func (s *keyedStates[T]) do(key string, now time.Time, fn func(state *T, seen bool) decision) decision {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if now.Sub(s.lastSweep) > s.ttl {
+		for k, st := range s.states {
+			if now.Sub(st.lastSeen) > s.ttl {
+				delete(s.states, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	st, ok := s.states[key]
+	if !ok {
+		st = &keyedState[T]{}
+		s.states[key] = st
+	}
+	d := fn(&st.state, ok)
+	st.lastSeen = now
+	return d
+}
+
+type bucketState struct {
+	tokens float64
+	last   time.Time
+}
+
+// tokenBucket refills requests/period tokens continuously up to the burst capacity.
+type tokenBucket struct {
+	limit    int64
+	capacity float64
+	// tokens per second
+	rate   float64
+	states *keyedStates[bucketState]
+}
+
+func newTokenBucket(requests, burst int64, period time.Duration) *tokenBucket {
+	if burst <= 0 {
+		burst = requests
+	}
+	rate := float64(requests) / period.Seconds()
+	// a bucket is full again after the refill time, the state could be evicted then
+	refill := time.Duration(float64(burst) / rate * float64(time.Second))
+	return &tokenBucket{
+		limit:    burst,
+		capacity: float64(burst),
+		rate:     rate,
+		states:   newKeyedStates[bucketState](max(refill, period)),
+	}
+}
+
+func (b *tokenBucket) take(_ context.Context, key string, now time.Time) decision {
+	return b.states.do(key, now, func(st *bucketState, seen bool) decision {
+		if !seen {
+			st.tokens = b.capacity
+		} else if elapsed := now.Sub(st.last).Seconds(); elapsed > 0 {
+			st.tokens = math.Min(b.capacity, st.tokens+elapsed*b.rate)
+		}
+		st.last = now
+		d := decision{limit: b.limit}
+		if st.tokens >= 1 {
+			st.tokens--
+			d.allowed = true
+			d.refund = func() { b.refund(key, now) }
+		} else {
+			d.retryAfter = b.duration(1 - st.tokens)
+		}
+		d.remaining = int64(st.tokens)
+		d.reset = b.duration(b.capacity - st.tokens)
+		return d
+	})
+}
+
+func (b *tokenBucket) refund(key string, now time.Time) {
+	b.states.do(key, now, func(st *bucketState, _ bool) decision {
+		st.tokens = math.Min(b.capacity, st.tokens+1)
+		return decision{}
+	})
+}
+
+func (b *tokenBucket) duration(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / b.rate * float64(time.Second)))
+}
+
+type windowState struct {
+	start    time.Time
+	current  int64
+	previous int64
+}
+
+// slidingWindow estimates the requests in the sliding window by weighting the previous fixed window.
+type slidingWindow struct {
+	limit  int64
+	period time.Duration
+	states *keyedStates[windowState]
+}
+
+func newSlidingWindow(requests int64, period time.Duration) *slidingWindow {
+	return &slidingWindow{
+		limit:  requests,
+		period: period,
+		states: newKeyedStates[windowState](2 * period),
+	}
+}
+
+func (w *slidingWindow) take(_ context.Context, key string, now time.Time) decision {
+	return w.states.do(key, now, func(st *windowState, _ bool) decision {
+		start := now.Truncate(w.period)
+		if !start.Equal(st.start) {
+			if start.Sub(st.start) == w.period {
+				st.previous = st.current
+			} else {
+				st.previous = 0
+			}
+			st.current = 0
+			st.start = start
+		}
+		elapsed := now.Sub(start)
+		weight := 1 - float64(elapsed)/float64(w.period)
+		estimated := int64(math.Ceil(float64(st.previous)*weight)) + st.current
+		d := decision{
+			limit: w.limit,
+			reset: w.period - elapsed,
+		}
+		if estimated < w.limit {
+			st.current++
+			estimated++
+			d.allowed = true
+			d.refund = func() { w.refund(key, start, now) }
+		} else {
+			d.retryAfter = w.period - elapsed
+		}
+		d.remaining = max(w.limit-estimated, 0)
+		return d
+	})
+}
+
+// refund decreases the counter of the window which begins at start, if it's still kept.
+func (w *slidingWindow) refund(key string, start, now time.Time) {
+	w.states.do(key, now, func(st *windowState, _ bool) decision {
+		switch {
+		case st.start.Equal(start) && st.current > 0:
+			st.current--
+		case st.start.Sub(start) == w.period && st.previous > 0:
+			st.previous--
+		}
+		return decision{}
+	})
+}
+
+func newLimiter(l *v1.Limit) limiter {
+	period := _defaultPeriod
+	if l.Period != nil && l.Period.AsDuration() > 0 {
+		period = l.Period.AsDuration()
+	}
+	if l.Algorithm == v1.Limit_SLIDING_WINDOW {
+		return newSlidingWindow(l.Requests, period)
+	}
+	return newTokenBucket(l.Requests, l.Burst, period)
+}
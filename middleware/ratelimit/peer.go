@@ -0,0 +1,438 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	_peerCountersPath = "/ratelimit/counters"
+	_peerSecretHeader = "X-Ratelimit-Secret"
+)
+
+var (
+	_defaultPeerSyncInterval = time.Second
+	_peerMaxMessageBytes     = int64(8 << 20)
+	// the max peers of a store, the pushes of the new peers beyond are rejected
+	_peerMaxNodes = 256
+	// the peers not pushed within the sync intervals are removed
+	_peerStaleIntervals = 3
+	// the max period of the counters pushed by the peers
+	_peerMaxPeriod = 24 * time.Hour
+)
+
+var _peerDiscovery = struct {
+	lock        sync.RWMutex
+	discovery   registry.Discovery
+	serviceName string
+}{}
+
+// SetDiscovery sets the discovery of the gateway instances for the peer store.
+func SetDiscovery(discovery registry.Discovery, serviceName string) {
+	_peerDiscovery.lock.Lock()
+	defer _peerDiscovery.lock.Unlock()
+	_peerDiscovery.discovery = discovery
+	_peerDiscovery.serviceName = serviceName
+}
+
+type peerCounter struct {
+	Key    string `json:"key"`
+	Start  int64  `json:"start"`
+	Period int64  `json:"period"`
+	Count  int64  `json:"count"`
+	Expire int64  `json:"-"`
+}
+
+// remoteCounters are the counters pushed by a peer last time.
+type remoteCounters struct {
+	counters map[windowKey]*peerCounter
+	lastPush time.Time
+}
+
+type peerMessage struct {
+	Node     string         `json:"node"`
+	Counters []*peerCounter `json:"counters"`
+}
+
+// PeerServer receives the counters pushed by the peers of the peer stores, it's served on the
+// listener created at the process startup, so that the address is kept over the config reloads.
+type PeerServer struct {
+	port      string
+	scheme    string
+	mutualTLS bool
+	transport http.RoundTripper
+	server    *http.Server
+
+	lock   sync.RWMutex
+	stores map[string]*peerStore
+}
+
+var _peerServer atomic.Pointer[PeerServer]
+
+// ServePeers serves the counters of the peer stores on the listener, the peers are pushed over tls
+// if tlsConfig is not nil. The shared secret of the peers is sent in the request header, so the tls
+// is required unless the network between the gateway instances is trusted. The peer stores require
+// the secret unless tlsConfig requires and verifies the client certificates.
+func ServePeers(lis net.Listener, tlsConfig *tls.Config) (*PeerServer, error) {
+	srv, err := newPeerServer(lis, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	_peerServer.Store(srv)
+	return srv, nil
+}
+
+func newPeerServer(lis net.Listener, tlsConfig *tls.Config) (*PeerServer, error) {
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	if err != nil {
+		return nil, err
+	}
+	srv := &PeerServer{
+		port:   port,
+		scheme: "http",
+		stores: make(map[string]*peerStore),
+	}
+	if tlsConfig != nil {
+		lis = tls.NewListener(lis, tlsConfig)
+		srv.scheme = "https"
+		srv.mutualTLS = tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert
+		srv.transport = &http.Transport{TLSClientConfig: tlsConfig.Clone()}
+	}
+	srv.server = &http.Server{Handler: http.HandlerFunc(srv.handleCounters), ReadHeaderTimeout: _defaultPeerSyncInterval}
+	go func() {
+		if err := srv.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("rate limit peer server stopped serving on %s: %v", lis.Addr(), err)
+		}
+	}()
+	log.Infof("rate limit peer server listening on %s", lis.Addr())
+	return srv, nil
+}
+
+// Close stops receiving the counters.
+func (srv *PeerServer) Close() error {
+	return srv.server.Close()
+}
+
+func (srv *PeerServer) handleCounters(w http.ResponseWriter, r *http.Request) {
+	srv.lock.RLock()
+	s, ok := srv.stores[strings.TrimPrefix(r.URL.Path, _peerCountersPath+"/")]
+	srv.lock.RUnlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.handleCounters(w, r)
+}
+
+// peerStore counts locally and pushes the local counters to the peers periodically,
+// the counters of a key are the sum of all the instances.
+type peerStore struct {
+	id       string
+	name     string
+	secret   string
+	interval time.Duration
+	server   *PeerServer
+	client   *http.Client
+	peers    atomic.Pointer[[]string]
+	cancel   context.CancelFunc
+
+	lock      sync.Mutex
+	local     map[windowKey]*peerCounter
+	remote    map[string]*remoteCounters
+	lastSweep time.Time
+}
+
+func newPeerStore(c *v1.Store) (Store, error) {
+	srv := _peerServer.Load()
+	if srv == nil {
+		return nil, errors.New("rate limit peer store requires the peer server, see -ratelimit.peer.addr")
+	}
+	return srv.newStore(c)
+}
+
+func (srv *PeerServer) newStore(c *v1.Store) (*peerStore, error) {
+	config := c.GetPeer()
+	if config.GetSecret() == "" && !srv.mutualTLS {
+		return nil, errors.New("rate limit peer store requires the secret unless the peer server verifies the client certificates")
+	}
+	interval := _defaultPeerSyncInterval
+	if config.SyncInterval != nil && config.SyncInterval.AsDuration() > 0 {
+		interval = config.SyncInterval.AsDuration()
+	}
+	// the stores of the same config share the name among the instances
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	s := &peerStore{
+		id:       uuid.New().String(),
+		name:     hex.EncodeToString(sum[:8]),
+		secret:   config.Secret,
+		interval: interval,
+		server:   srv,
+		client:   &http.Client{Timeout: interval, Transport: srv.transport},
+		local:    make(map[windowKey]*peerCounter),
+		remote:   make(map[string]*remoteCounters),
+	}
+	if s.secret != "" && srv.scheme != "https" {
+		log.Warnf("rate limit peer store sends the secret in plain text, the peer server should be served with tls")
+	}
+	srv.lock.Lock()
+	srv.stores[s.name] = s
+	srv.lock.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	peers := config.Peers
+	s.peers.Store(&peers)
+	if len(peers) == 0 {
+		s.watchPeers(ctx, srv.port)
+	}
+	go s.run(ctx)
+	log.Infof("rate limit peer store %s receiving the counters of %s", s.id, s.name)
+	return s, nil
+}
+
+// watchPeers updates the peers by the gateway instances in discovery.
+func (s *peerStore) watchPeers(ctx context.Context, port string) {
+	_peerDiscovery.lock.RLock()
+	discovery, serviceName := _peerDiscovery.discovery, _peerDiscovery.serviceName
+	_peerDiscovery.lock.RUnlock()
+	if discovery == nil || serviceName == "" {
+		log.Warnf("rate limit peer store has no peers and discovery, the counters are not shared")
+		return
+	}
+	watcher, err := discovery.Watch(ctx, serviceName)
+	if err != nil {
+		log.Errorf("failed to watch the rate limit peers of %s: %v", serviceName, err)
+		return
+	}
+	go func() {
+		<-ctx.Done()
+		_ = watcher.Stop()
+	}()
+	go func() {
+		for {
+			instances, err := watcher.Next()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Errorf("failed to watch the rate limit peers of %s: %v", serviceName, err)
+				time.Sleep(s.interval)
+				continue
+			}
+			peers := make([]string, 0, len(instances))
+			for _, instance := range instances {
+				for _, endpoint := range instance.Endpoints {
+					u, err := url.Parse(endpoint)
+					if err != nil || u.Hostname() == "" {
+						continue
+					}
+					peers = append(peers, net.JoinHostPort(u.Hostname(), port))
+					break
+				}
+			}
+			s.peers.Store(&peers)
+		}
+	}()
+}
+
+func (s *peerStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.interval {
+		return
+	}
+	s.lastSweep = now
+	for k, c := range s.local {
+		if now.UnixNano() > c.Expire {
+			delete(s.local, k)
+		}
+	}
+	for node, remote := range s.remote {
+		if now.Sub(remote.lastPush) > time.Duration(_peerStaleIntervals)*s.interval {
+			delete(s.remote, node)
+			continue
+		}
+		for k, c := range remote.counters {
+			if now.UnixNano() > c.Expire {
+				delete(remote.counters, k)
+			}
+		}
+	}
+}
+
+func (s *peerStore) count(k windowKey) int64 {
+	var count int64
+	if c, ok := s.local[k]; ok {
+		count += c.Count
+	}
+	for _, remote := range s.remote {
+		if c, ok := remote.counters[k]; ok {
+			count += c.Count
+		}
+	}
+	return count
+}
+
+func (s *peerStore) Increment(_ context.Context, key string, start time.Time, period time.Duration) (int64, int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sweep(time.Now())
+	k := windowKey{key: key, start: start.UnixNano()}
+	c, ok := s.local[k]
+	if !ok {
+		c = &peerCounter{Key: key, Start: k.start, Period: int64(period), Expire: start.Add(2 * period).UnixNano()}
+		s.local[k] = c
+	}
+	c.Count++
+	return s.count(k), s.count(windowKey{key: key, start: start.Add(-period).UnixNano()}), nil
+}
+
+func (s *peerStore) Decrement(_ context.Context, key string, start time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if c, ok := s.local[windowKey{key: key, start: start.UnixNano()}]; ok && c.Count > 0 {
+		c.Count--
+	}
+	return nil
+}
+
+func (s *peerStore) handleCounters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// the secret is required unless the client certificates are verified by the peer server
+	if s.secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(_peerSecretHeader)), []byte(s.secret)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	msg := &peerMessage{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, _peerMaxMessageBytes)).Decode(msg); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if msg.Node == "" || msg.Node == s.id {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	now := time.Now()
+	counters := make(map[windowKey]*peerCounter, len(msg.Counters))
+	for _, c := range msg.Counters {
+		period := time.Duration(c.Period)
+		if c.Count <= 0 || period <= 0 || period > _peerMaxPeriod || c.Start > now.Add(period).UnixNano() {
+			continue
+		}
+		// the counters expire by the windows, the peers could not keep them longer
+		c.Expire = c.Start + int64(2*period)
+		counters[windowKey{key: c.Key, start: c.Start}] = c
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sweep(now)
+	if _, ok := s.remote[msg.Node]; !ok && len(s.remote) >= _peerMaxNodes {
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	// the message carries all the counters of the peer
+	s.remote[msg.Node] = &remoteCounters{counters: counters, lastPush: now}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *peerStore) snapshot() []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sweep(time.Now())
+	if len(s.local) == 0 {
+		return nil
+	}
+	msg := &peerMessage{Node: s.id, Counters: make([]*peerCounter, 0, len(s.local))}
+	for _, c := range s.local {
+		copied := *c
+		msg.Counters = append(msg.Counters, &copied)
+	}
+	b, _ := json.Marshal(msg)
+	return b
+}
+
+func (s *peerStore) push(ctx context.Context, peer string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.server.scheme+"://"+peer+_peerCountersPath+"/"+s.name, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.secret != "" {
+		req.Header.Set(_peerSecretHeader, s.secret)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+func (s *peerStore) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		body := s.snapshot()
+		if body == nil {
+			continue
+		}
+		var wg sync.WaitGroup
+		for _, peer := range *s.peers.Load() {
+			wg.Add(1)
+			go func(peer string) {
+				defer wg.Done()
+				if err := s.push(ctx, peer, body); err != nil && ctx.Err() == nil {
+					log.Warnf("failed to push rate limit counters to peer %s: %v", peer, err)
+				}
+			}(peer)
+		}
+		wg.Wait()
+	}
+}
+
+func (s *peerStore) Close() error {
+	s.server.lock.Lock()
+	if s.server.stores[s.name] == s {
+		delete(s.server.stores, s.name)
+	}
+	s.server.lock.Unlock()
+	s.cancel()
+	return nil
+}
@@ -0,0 +1,196 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/gateway/middleware/jwt"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	headerLimit     = "X-RateLimit-Limit"
+	headerRemaining = "X-RateLimit-Remaining"
+	headerReset     = "X-RateLimit-Reset"
+	headerRetry     = "Retry-After"
+)
+
+var _metricLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_rate_limited_total",
+	Help:      "The total number of rate limited requests",
+}, []string{"protocol", "method", "path", "service", "basePath", "limit"})
+
+func init() {
+	prometheus.MustRegister(_metricLimitedTotal)
+	middleware.RegisterV2("ratelimit", Middleware)
+}
+
+type keyFunc func(*http.Request) (string, bool)
+
+func newKeyFunc(key *v1.Key) (keyFunc, error) {
+	switch source := key.GetSource().(type) {
+	case *v1.Key_ClientIp:
+		return func(req *http.Request) (string, bool) {
+			ip, _, err := net.SplitHostPort(req.RemoteAddr)
+			if err != nil {
+				return req.RemoteAddr, req.RemoteAddr != ""
+			}
+			return ip, true
+		}, nil
+	case *v1.Key_Header:
+		return func(req *http.Request) (string, bool) {
+			v := req.Header.Get(source.Header)
+			return v, v != ""
+		}, nil
+	case *v1.Key_JwtClaim:
+		return func(req *http.Request) (string, bool) {
+			claims, ok := jwt.ClaimsFromContext(req.Context())
+			if !ok {
+				return "", false
+			}
+			claim, ok := claims.Get(source.JwtClaim)
+			if !ok {
+				return "", false
+			}
+			return fmt.Sprint(claim), true
+		}, nil
+	case *v1.Key_PathVariable:
+		return func(req *http.Request) (string, bool) {
+			v, ok := mux.Vars(req)[source.PathVariable]
+			return v, ok
+		}, nil
+	case nil:
+		return func(*http.Request) (string, bool) { return "", true }, nil
+	}
+	return nil, fmt.Errorf("unknown rate limit key: %+v", key)
+}
+
+type limit struct {
+	name    string
+	key     keyFunc
+	limiter limiter
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+func setHeaders(header http.Header, d decision) {
+	header.Set(headerLimit, strconv.FormatInt(d.limit, 10))
+	header.Set(headerRemaining, strconv.FormatInt(d.remaining, 10))
+	header.Set(headerReset, seconds(d.reset))
+}
+
+func limitedIncr(req *http.Request, name string) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricLimitedTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath(), name).Inc()
+	}
+}
+
+// Middleware limits the request rate of the endpoint.
+func Middleware(c *config.Middleware) (middleware.MiddlewareV2, error) {
+	options := &v1.RateLimit{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if len(options.Limits) == 0 {
+		return nil, errors.New("at least one rate limit is required")
+	}
+	limits := make([]*limit, 0, len(options.Limits))
+	for i, l := range options.Limits {
+		if l.Requests <= 0 {
+			return nil, fmt.Errorf("invalid requests of rate limit: %d", l.Requests)
+		}
+		key, err := newKeyFunc(l.Key)
+		if err != nil {
+			return nil, err
+		}
+		name := l.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		limits = append(limits, &limit{
+			name:    name,
+			key:     key,
+			limiter: newLimiter(l),
+		})
+	}
+	var closer io.Closer = middleware.NopCloser
+	if options.Store != nil {
+		store, err := acquireStore(options.Store)
+		if err != nil {
+			return nil, err
+		}
+		for i, l := range options.Limits {
+			limits[i].limiter = newStoreLimiter(store, limits[i].name+":", l)
+		}
+		closer = store
+	}
+	return middleware.NewWithCloser(func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the retries are not counted again
+			if middleware.IsRetryAttempt(req.Context()) {
+				return next.RoundTrip(req)
+			}
+			now := time.Now()
+			var (
+				applied    bool
+				restricted decision
+				taken      []decision
+			)
+			for _, l := range limits {
+				key, ok := l.key(req)
+				if !ok {
+					continue
+				}
+				d := l.limiter.take(req.Context(), key, now)
+				if !d.allowed {
+					// the rejected request doesn't consume the quotas of the other limits
+					for _, t := range taken {
+						t.refund()
+					}
+					limitedIncr(req, l.name)
+					resp := middleware.NewErrorResponse(req, http.StatusTooManyRequests, "rate limit exceeded")
+					if !options.DisableResponseHeaders {
+						setHeaders(resp.Header, d)
+						resp.Header.Set(headerRetry, seconds(d.retryAfter))
+					}
+					return resp, nil
+				}
+				taken = append(taken, d)
+				if !applied || d.remaining < restricted.remaining {
+					restricted = d
+					applied = true
+				}
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if applied && !options.DisableResponseHeaders {
+				if resp.Header == nil {
+					resp.Header = make(http.Header)
+				}
+				setHeaders(resp.Header, restricted)
+			}
+			return resp, nil
+		})
+	}, closer), nil
+}
@@ -0,0 +1,192 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestTokenBucket(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	b := newTokenBucket(2, 4, time.Second)
+	for i := 0; i < 4; i++ {
+		if d := b.take(ctx, "a", now); !d.allowed || d.remaining != int64(3-i) {
+			t.Fatalf("expected request %d to be allowed with %d remaining, got %+v", i, 3-i, d)
+		}
+	}
+	d := b.take(ctx, "a", now)
+	if d.allowed || d.retryAfter != 500*time.Millisecond || d.reset != 2*time.Second {
+		t.Fatalf("expected request to be limited, got %+v", d)
+	}
+	if d := b.take(ctx, "b", now); !d.allowed {
+		t.Fatalf("expected another key to be allowed, got %+v", d)
+	}
+	if d := b.take(ctx, "a", now.Add(500*time.Millisecond)); !d.allowed || d.remaining != 0 {
+		t.Fatalf("expected request to be allowed after refill, got %+v", d)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Truncate(time.Second)
+	w := newSlidingWindow(2, time.Second)
+	for i := 0; i < 2; i++ {
+		if d := w.take(ctx, "a", start); !d.allowed {
+			t.Fatalf("expected request %d to be allowed, got %+v", i, d)
+		}
+	}
+	if d := w.take(ctx, "a", start.Add(900*time.Millisecond)); d.allowed || d.retryAfter != 100*time.Millisecond {
+		t.Fatalf("expected request to be limited, got %+v", d)
+	}
+	// the previous window is weighted by 75%
+	if d := w.take(ctx, "a", start.Add(1250*time.Millisecond)); d.allowed {
+		t.Fatalf("expected request to be limited by the previous window, got %+v", d)
+	}
+	if d := w.take(ctx, "a", start.Add(1600*time.Millisecond)); !d.allowed || d.remaining != 0 {
+		t.Fatalf("expected request to be allowed, got %+v", d)
+	}
+	if d := w.take(ctx, "a", start.Add(3*time.Second)); !d.allowed || d.remaining != 1 {
+		t.Fatalf("expected request to be allowed after idle windows, got %+v", d)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// nothing listens on the address
+	unavailable := ln.Addr().String()
+	ln.Close()
+	type request struct {
+		// the index of the middlewares created by the same options
+		instance   int
+		grpc       bool
+		header     http.Header
+		statusCode int
+		respHeader http.Header
+	}
+	refund := func(store *v1.Store) *v1.RateLimit {
+		return &v1.RateLimit{
+			Limits: []*v1.Limit{
+				{Name: "global", Requests: 2, Period: durationpb.New(time.Minute)},
+				{Name: "user", Key: &v1.Key{Source: &v1.Key_Header{Header: "X-User"}}, Requests: 1, Period: durationpb.New(time.Minute)},
+				{Name: "window", Requests: 2, Period: durationpb.New(time.Minute), Algorithm: v1.Limit_SLIDING_WINDOW},
+			},
+			Store: store,
+		}
+	}
+	// the requests limited by the user limit don't consume the global quotas
+	refundRequests := []request{
+		{header: http.Header{"X-User": {"a"}}, statusCode: http.StatusOK},
+		{header: http.Header{"X-User": {"a"}}, statusCode: http.StatusTooManyRequests},
+		{header: http.Header{"X-User": {"a"}}, statusCode: http.StatusTooManyRequests},
+		{header: http.Header{"X-User": {"a"}}, statusCode: http.StatusTooManyRequests},
+		{header: http.Header{"X-User": {"b"}}, statusCode: http.StatusOK},
+		{header: http.Header{"X-User": {"c"}}, statusCode: http.StatusTooManyRequests},
+	}
+	tests := []struct {
+		name      string
+		options   *v1.RateLimit
+		instances int
+		requests  []request
+	}{
+		{
+			name: "key",
+			options: &v1.RateLimit{
+				Limits: []*v1.Limit{{
+					Key:      &v1.Key{Source: &v1.Key_Header{Header: "X-Api-Key"}},
+					Requests: 1,
+					Period:   durationpb.New(time.Minute),
+				}},
+			},
+			requests: []request{
+				{header: http.Header{"X-Api-Key": {"a"}}, statusCode: http.StatusOK, respHeader: http.Header{headerLimit: {"1"}, headerRemaining: {"0"}}},
+				{header: http.Header{"X-Api-Key": {"a"}}, statusCode: http.StatusTooManyRequests, respHeader: http.Header{headerRetry: {"60"}}},
+				{header: http.Header{"X-Api-Key": {"a"}}, grpc: true, statusCode: http.StatusOK, respHeader: http.Header{"Grpc-Status": {"8"}}},
+				// the limit is skipped without the key
+				{statusCode: http.StatusOK, respHeader: http.Header{headerLimit: {""}}},
+			},
+		},
+		{name: "refund", options: refund(nil), requests: refundRequests},
+		{name: "refund memory store", options: refund(&v1.Store{Backend: &v1.Store_Memory{Memory: &v1.MemoryStore{}}}), requests: refundRequests},
+		{
+			// the middlewares of the reloaded config share the counters
+			name: "memory store",
+			options: &v1.RateLimit{
+				Limits: []*v1.Limit{{Requests: 2, Period: durationpb.New(time.Hour)}},
+				Store:  &v1.Store{Backend: &v1.Store_Memory{Memory: &v1.MemoryStore{}}},
+			},
+			instances: 2,
+			requests: []request{
+				{instance: 0, statusCode: http.StatusOK},
+				{instance: 1, statusCode: http.StatusOK},
+				{instance: 0, statusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			// the requests are limited locally if the store is unavailable
+			name: "store fallback",
+			options: &v1.RateLimit{
+				Limits: []*v1.Limit{{Requests: 1, Period: durationpb.New(time.Hour)}},
+				Store:  &v1.Store{Backend: &v1.Store_Redis{Redis: &v1.RedisStore{Address: unavailable}}},
+			},
+			requests: []request{
+				{statusCode: http.StatusOK},
+				{statusCode: http.StatusTooManyRequests},
+			},
+		},
+	}
+	next := middleware.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+	})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := anypb.New(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var instances []middleware.MiddlewareV2
+			for i := 0; i < max(test.instances, 1); i++ {
+				m, err := Middleware(&config.Middleware{Options: options})
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer m.Close()
+				instances = append(instances, m)
+			}
+			for i, r := range test.requests {
+				req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+				for k, v := range r.header {
+					req.Header[k] = v
+				}
+				protocol := config.Protocol_HTTP
+				if r.grpc {
+					protocol = config.Protocol_GRPC
+				}
+				ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{Path: "/", Protocol: protocol}))
+				resp, err := instances[r.instance].Process(next).RoundTrip(req.WithContext(ctx))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.StatusCode != r.statusCode {
+					t.Fatalf("request %d: expected status code %d, got %d", i, r.statusCode, resp.StatusCode)
+				}
+				for k, v := range r.respHeader {
+					if got := resp.Header.Get(k); got != v[0] {
+						t.Fatalf("request %d: expected header %s %q, got %q", i, k, v[0], got)
+					}
+				}
+			}
+		})
+	}
+}
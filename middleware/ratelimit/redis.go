@@ -0,0 +1,239 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+)
+
+var _defaultRedisPoolSize = 16
+
+var errRedisNil = errors.New("redis: nil")
+
+// redisError is the error reply of the server, the connection is still usable.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+type redisConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+	bw   *bufio.Writer
+}
+
+// writeCommand writes the command in the RESP array of bulk strings.
+func (c *redisConn) writeCommand(args ...string) {
+	fmt.Fprintf(c.bw, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(c.bw, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+}
+
+func (c *redisConn) readLine() (string, error) {
+	line, err := c.br.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: invalid reply line: %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// readReply reads a reply, integers and bulk strings are returned as string.
+func (c *redisConn) readReply() (string, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", err
+		}
+		if size < 0 {
+			return "", errRedisNil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(c.br, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:size]), nil
+	}
+	return "", fmt.Errorf("redis: unsupported reply: %q", line)
+}
+
+// do pipelines the commands and returns the replies.
+func (c *redisConn) do(ctx context.Context, cmds ...[]string) ([]string, []error, error) {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, nil, err
+	}
+	for _, cmd := range cmds {
+		c.writeCommand(cmd...)
+	}
+	if err := c.bw.Flush(); err != nil {
+		return nil, nil, err
+	}
+	replies := make([]string, len(cmds))
+	errs := make([]error, len(cmds))
+	for i := range cmds {
+		replies[i], errs[i] = c.readReply()
+		var replyErr redisError
+		if errs[i] != nil && !errors.Is(errs[i], errRedisNil) && !errors.As(errs[i], &replyErr) {
+			return nil, nil, errs[i]
+		}
+	}
+	return replies, errs, nil
+}
+
+// redisStore counts in a Redis protocol compatible server.
+type redisStore struct {
+	config *v1.RedisStore
+	dialer net.Dialer
+	lock   sync.Mutex
+	idle   []*redisConn
+	size   int
+	closed bool
+}
+
+func newRedisStore(c *v1.Store) (Store, error) {
+	config := c.GetRedis()
+	if config.GetAddress() == "" {
+		return nil, errors.New("redis address is required")
+	}
+	size := _defaultRedisPoolSize
+	if config.PoolSize > 0 {
+		size = int(config.PoolSize)
+	}
+	return &redisStore{config: config, size: size}, nil
+}
+
+func (s *redisStore) dial(ctx context.Context) (*redisConn, error) {
+	conn, err := s.dialer.DialContext(ctx, "tcp", s.config.Address)
+	if err != nil {
+		return nil, err
+	}
+	c := &redisConn{conn: conn, br: bufio.NewReader(conn), bw: bufio.NewWriter(conn)}
+	var cmds [][]string
+	if s.config.Password != "" {
+		if s.config.Username != "" {
+			cmds = append(cmds, []string{"AUTH", s.config.Username, s.config.Password})
+		} else {
+			cmds = append(cmds, []string{"AUTH", s.config.Password})
+		}
+	}
+	if s.config.Db > 0 {
+		cmds = append(cmds, []string{"SELECT", strconv.Itoa(int(s.config.Db))})
+	}
+	if len(cmds) == 0 {
+		return c, nil
+	}
+	_, errs, err := c.do(ctx, cmds...)
+	if err == nil {
+		err = errors.Join(errs...)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *redisStore) get(ctx context.Context) (*redisConn, error) {
+	s.lock.Lock()
+	if n := len(s.idle); n > 0 {
+		c := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.lock.Unlock()
+		return c, nil
+	}
+	s.lock.Unlock()
+	return s.dial(ctx)
+}
+
+func (s *redisStore) put(c *redisConn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed || len(s.idle) >= s.size {
+		c.conn.Close()
+		return
+	}
+	s.idle = append(s.idle, c)
+}
+
+func (s *redisStore) Increment(ctx context.Context, key string, start time.Time, period time.Duration) (int64, int64, error) {
+	c, err := s.get(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	current := key + ":" + strconv.FormatInt(start.UnixMilli(), 10)
+	previous := key + ":" + strconv.FormatInt(start.Add(-period).UnixMilli(), 10)
+	replies, errs, err := c.do(ctx,
+		[]string{"INCR", current},
+		[]string{"PEXPIRE", current, strconv.FormatInt((2 * period).Milliseconds(), 10)},
+		[]string{"GET", previous},
+	)
+	if err != nil {
+		c.conn.Close()
+		return 0, 0, err
+	}
+	s.put(c)
+	if errs[0] != nil {
+		return 0, 0, errs[0]
+	}
+	cur, err := strconv.ParseInt(replies[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if errs[2] != nil {
+		if errors.Is(errs[2], errRedisNil) {
+			return cur, 0, nil
+		}
+		return 0, 0, errs[2]
+	}
+	prev, err := strconv.ParseInt(replies[2], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cur, prev, nil
+}
+
+func (s *redisStore) Decrement(ctx context.Context, key string, start time.Time) error {
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	// the counter was just increased, it expires after the next window
+	_, errs, err := c.do(ctx, []string{"DECR", key + ":" + strconv.FormatInt(start.UnixMilli(), 10)})
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+	s.put(c)
+	return errs[0]
+}
+
+func (s *redisStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	for _, c := range s.idle {
+		c.conn.Close()
+	}
+	s.idle = nil
+	return nil
+}
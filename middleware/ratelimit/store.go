@@ -0,0 +1,291 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	_defaultStoreTimeout          = 50 * time.Millisecond
+	_defaultStoreFallbackDuration = 5 * time.Second
+	_defaultStoreKeyPrefix        = "gateway:ratelimit:"
+)
+
+var _metricStoreFallbackTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "ratelimit_store_fallback_total",
+	Help:      "The total number of rate limit decisions made locally due to store failures",
+}, []string{"store"})
+
+func init() {
+	prometheus.MustRegister(_metricStoreFallbackTotal)
+}
+
+// Store is the shared counter backend of the distributed rate limits.
+type Store interface {
+	// Increment increases the counter of the key in the fixed window which begins at start,
+	// and returns the counters of the current and the previous windows.
+	Increment(ctx context.Context, key string, start time.Time, period time.Duration) (current, previous int64, err error)
+	// Decrement decreases the counter of the key in the fixed window which begins at start,
+	// it refunds the request counted by Increment.
+	Decrement(ctx context.Context, key string, start time.Time) error
+	Close() error
+}
+
+// StoreFactory creates the store by config.
+type StoreFactory func(*v1.Store) (Store, error)
+
+var _storeFactories = map[string]StoreFactory{
+	"memory": func(*v1.Store) (Store, error) { return newMemoryStore(), nil },
+	"redis":  newRedisStore,
+	"peer":   newPeerStore,
+}
+
+func storeType(c *v1.Store) string {
+	switch c.Backend.(type) {
+	case *v1.Store_Memory:
+		return "memory"
+	case *v1.Store_Redis:
+		return "redis"
+	case *v1.Store_Peer:
+		return "peer"
+	}
+	return ""
+}
+
+// sharedStore is shared by the middlewares with the same store config,
+// so that the counters and connections survive the config reloads.
+type sharedStore struct {
+	Store
+	name             string
+	config           string
+	refs             int
+	timeout          time.Duration
+	fallbackDuration time.Duration
+	keyPrefix        string
+	// the unix nano until which the local limits are used
+	fallbackUntil atomic.Int64
+}
+
+var _stores = struct {
+	lock sync.Mutex
+	m    map[string]*sharedStore
+}{m: make(map[string]*sharedStore)}
+
+func acquireStore(c *v1.Store) (*sharedStore, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	key := string(b)
+	_stores.lock.Lock()
+	defer _stores.lock.Unlock()
+	if s, ok := _stores.m[key]; ok {
+		s.refs++
+		return s, nil
+	}
+	name := storeType(c)
+	factory, ok := _storeFactories[name]
+	if !ok {
+		return nil, errors.New("rate limit store backend is required")
+	}
+	store, err := factory(c)
+	if err != nil {
+		return nil, err
+	}
+	s := &sharedStore{
+		Store:            store,
+		name:             name,
+		config:           key,
+		refs:             1,
+		timeout:          _defaultStoreTimeout,
+		fallbackDuration: _defaultStoreFallbackDuration,
+		keyPrefix:        _defaultStoreKeyPrefix,
+	}
+	if c.Timeout != nil && c.Timeout.AsDuration() > 0 {
+		s.timeout = c.Timeout.AsDuration()
+	}
+	if c.FallbackDuration != nil && c.FallbackDuration.AsDuration() > 0 {
+		s.fallbackDuration = c.FallbackDuration.AsDuration()
+	}
+	if c.KeyPrefix != "" {
+		s.keyPrefix = c.KeyPrefix
+	}
+	_stores.m[key] = s
+	return s, nil
+}
+
+// Close releases the store, it's closed after all the middlewares are closed.
+func (s *sharedStore) Close() error {
+	_stores.lock.Lock()
+	defer _stores.lock.Unlock()
+	s.refs--
+	if s.refs > 0 {
+		return nil
+	}
+	delete(_stores.m, s.config)
+	return s.Store.Close()
+}
+
+// increment calls the store unless it failed recently.
+func (s *sharedStore) increment(ctx context.Context, key string, start time.Time, period time.Duration) (int64, int64, error) {
+	if time.Now().UnixNano() < s.fallbackUntil.Load() {
+		return 0, 0, errStoreFallback
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	current, previous, err := s.Increment(ctx, s.keyPrefix+key, start, period)
+	if err != nil {
+		if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// the request is canceled, the store is not to blame
+			return 0, 0, err
+		}
+		s.fallbackUntil.Store(time.Now().Add(s.fallbackDuration).UnixNano())
+		log.Errorf("rate limit store %s failed, use local limits for %s: %v", s.name, s.fallbackDuration, err)
+		return 0, 0, err
+	}
+	return current, previous, nil
+}
+
+// decrement refunds the request counted by increment.
+func (s *sharedStore) decrement(ctx context.Context, key string, start time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.Decrement(ctx, s.keyPrefix+key, start)
+}
+
+var errStoreFallback = errors.New("rate limit store is in fallback")
+
+// storeLimiter counts the requests by the sliding window in the store,
+// and falls back to the local limiter when the store is unavailable.
+type storeLimiter struct {
+	store  *sharedStore
+	prefix string
+	limit  int64
+	period time.Duration
+	local  limiter
+}
+
+func newStoreLimiter(store *sharedStore, prefix string, l *v1.Limit) *storeLimiter {
+	period := _defaultPeriod
+	if l.Period != nil && l.Period.AsDuration() > 0 {
+		period = l.Period.AsDuration()
+	}
+	return &storeLimiter{
+		store:  store,
+		prefix: prefix,
+		limit:  l.Requests,
+		period: period,
+		local:  newLimiter(l),
+	}
+}
+
+// endpointKey identifies the endpoint of the request among the gateway instances.
+func endpointKey(ctx context.Context) string {
+	endpoint, ok := middleware.EndpointFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return endpoint.Host + ":" + endpoint.Method + ":" + endpoint.Path + ":"
+}
+
+func (l *storeLimiter) take(ctx context.Context, key string, now time.Time) decision {
+	start := now.Truncate(l.period)
+	storeKey := l.prefix + endpointKey(ctx) + key
+	current, previous, err := l.store.increment(ctx, storeKey, start, l.period)
+	if err != nil {
+		_metricStoreFallbackTotal.WithLabelValues(l.store.name).Inc()
+		return l.local.take(ctx, key, now)
+	}
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(l.period)
+	// the current counter includes this request
+	estimated := int64(math.Ceil(float64(previous)*weight)) + current
+	d := decision{
+		limit:     l.limit,
+		reset:     l.period - elapsed,
+		remaining: max(l.limit-estimated, 0),
+		allowed:   estimated <= l.limit,
+	}
+	if d.allowed {
+		d.refund = func() {
+			if err := l.store.decrement(ctx, storeKey, start); err != nil {
+				log.Warnf("failed to refund rate limit %s to store %s: %v", storeKey, l.store.name, err)
+			}
+		}
+	} else {
+		d.retryAfter = l.period - elapsed
+	}
+	return d
+}
+
+type windowKey struct {
+	key   string
+	start int64
+}
+
+type windowCounter struct {
+	count  int64
+	expire time.Time
+}
+
+// memoryStore keeps the counters in the process.
+type memoryStore struct {
+	lock      sync.Mutex
+	counters  map[windowKey]*windowCounter
+	lastSweep time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{counters: make(map[windowKey]*windowCounter)}
+}
+
+func (s *memoryStore) Increment(_ context.Context, key string, start time.Time, period time.Duration) (int64, int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > period {
+		for k, c := range s.counters {
+			if now.After(c.expire) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	k := windowKey{key: key, start: start.UnixNano()}
+	c, ok := s.counters[k]
+	if !ok {
+		c = &windowCounter{expire: start.Add(2 * period)}
+		s.counters[k] = c
+	}
+	c.count++
+	var previous int64
+	if p, ok := s.counters[windowKey{key: key, start: start.Add(-period).UnixNano()}]; ok {
+		previous = p.count
+	}
+	return c.count, previous, nil
+}
+
+func (s *memoryStore) Decrement(_ context.Context, key string, start time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if c, ok := s.counters[windowKey{key: key, start: start.UnixNano()}]; ok && c.count > 0 {
+		c.count--
+	}
+	return nil
+}
+
+func (s *memoryStore) Close() error { return nil }
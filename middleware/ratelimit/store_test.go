@@ -0,0 +1,260 @@
+package ratelimit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeRedis serves INCR, PEXPIRE and GET of the RESP protocol.
+func fakeRedis(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	var (
+		lock     sync.Mutex
+		counters = make(map[string]int64)
+	)
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		br := bufio.NewReader(conn)
+		for {
+			line, err := br.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+			args := make([]string, n)
+			for i := range args {
+				line, err := br.ReadString('\n')
+				if err != nil {
+					return
+				}
+				size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+				buf := make([]byte, size+2)
+				if _, err := io.ReadFull(br, buf); err != nil {
+					return
+				}
+				args[i] = string(buf[:size])
+			}
+			lock.Lock()
+			switch strings.ToUpper(args[0]) {
+			case "INCR":
+				counters[args[1]]++
+				fmt.Fprintf(conn, ":%d\r\n", counters[args[1]])
+			case "DECR":
+				counters[args[1]]--
+				fmt.Fprintf(conn, ":%d\r\n", counters[args[1]])
+			case "PEXPIRE":
+				fmt.Fprint(conn, ":1\r\n")
+			case "GET":
+				if v, ok := counters[args[1]]; ok {
+					s := strconv.FormatInt(v, 10)
+					fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(s), s)
+				} else {
+					fmt.Fprint(conn, "$-1\r\n")
+				}
+			default:
+				fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+			}
+			lock.Unlock()
+		}
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestRedisStore(t *testing.T) {
+	store, err := newRedisStore(&v1.Store{Backend: &v1.Store_Redis{Redis: &v1.RedisStore{Address: fakeRedis(t)}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	start := time.Now().Truncate(time.Minute)
+	for i := 1; i <= 3; i++ {
+		current, previous, err := store.Increment(ctx, "a", start, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if current != int64(i) || previous != 0 {
+			t.Fatalf("expected counters %d/0, got %d/%d", i, current, previous)
+		}
+	}
+	if err := store.Decrement(ctx, "a", start); err != nil {
+		t.Fatal(err)
+	}
+	current, previous, err := store.Increment(ctx, "a", start.Add(time.Minute), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != 1 || previous != 2 {
+		t.Fatalf("expected counters 1/2, got %d/%d", current, previous)
+	}
+}
+
+func TestPeerStore(t *testing.T) {
+	newServer := func() (*PeerServer, string) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv, err := newPeerServer(ln, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { srv.Close() })
+		return srv, ln.Addr().String()
+	}
+	srv1, addr1 := newServer()
+	srv2, addr2 := newServer()
+	newStore := func(srv *PeerServer) Store {
+		s, err := srv.newStore(&v1.Store{Backend: &v1.Store_Peer{Peer: &v1.PeerStore{
+			Peers:        []string{addr1, addr2},
+			SyncInterval: durationpb.New(10 * time.Millisecond),
+			Secret:       "secret",
+		}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	}
+	// the instances share the same config, the counters pushed to itself are ignored
+	s1, s2 := newStore(srv1), newStore(srv2)
+	ctx := context.Background()
+	start := time.Now().Truncate(time.Hour)
+	for i := 0; i < 2; i++ {
+		if _, _, err := s1.Increment(ctx, "a", start, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		current, _, err := s2.Increment(ctx, "a", start, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// s2 counts 1 more on every attempt
+		s2.(*peerStore).lock.Lock()
+		local := s2.(*peerStore).local[windowKey{key: "a", start: start.UnixNano()}].Count
+		s2.(*peerStore).lock.Unlock()
+		if current == local+2 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the counters of the peer to be synced, got %d", current)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPeerStoreCounters(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := newPeerServer(ln, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	if _, err := srv.newStore(&v1.Store{Backend: &v1.Store_Peer{Peer: &v1.PeerStore{}}}); err == nil {
+		t.Fatal("expected the peer store without the secret to be rejected")
+	}
+	store, err := srv.newStore(&v1.Store{Backend: &v1.Store_Peer{Peer: &v1.PeerStore{
+		SyncInterval: durationpb.New(time.Hour),
+		Secret:       "secret",
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	s := store
+	start := time.Now().Truncate(time.Minute).UnixNano()
+	push := func(node string, counters ...*peerCounter) int {
+		body, _ := json.Marshal(&peerMessage{Node: node, Counters: counters})
+		req := httptest.NewRequest(http.MethodPost, _peerCountersPath+"/"+s.name, bytes.NewReader(body))
+		req.Header.Set(_peerSecretHeader, "secret")
+		w := httptest.NewRecorder()
+		s.handleCounters(w, req)
+		return w.Code
+	}
+	tests := []struct {
+		name    string
+		counter *peerCounter
+		expire  int64
+	}{
+		{"expire", &peerCounter{Key: "a", Start: start, Period: int64(time.Minute), Count: 1, Expire: math.MaxInt64}, start + int64(2*time.Minute)},
+		{"no period", &peerCounter{Key: "a", Start: start, Count: 1}, 0},
+		{"long period", &peerCounter{Key: "a", Start: start, Period: int64(2 * _peerMaxPeriod), Count: 1}, 0},
+		{"future", &peerCounter{Key: "a", Start: start + int64(time.Hour), Period: int64(time.Minute), Count: 1}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if code := push("node", test.counter); code != http.StatusNoContent {
+				t.Fatalf("expected status %d, got %d", http.StatusNoContent, code)
+			}
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			c, ok := s.remote["node"].counters[windowKey{key: test.counter.Key, start: test.counter.Start}]
+			if test.expire == 0 {
+				if ok {
+					t.Fatalf("expected the counter to be dropped, got %+v", c)
+				}
+				return
+			}
+			if !ok || c.Expire != test.expire {
+				t.Fatalf("expected the counter expire %d, got %+v", test.expire, c)
+			}
+		})
+	}
+
+	// the peers not pushed within the sync intervals are removed
+	s.lock.Lock()
+	s.remote["node"].lastPush = time.Now().Add(-time.Duration(_peerStaleIntervals+1) * s.interval)
+	s.lastSweep = time.Time{}
+	s.sweep(time.Now())
+	_, ok := s.remote["node"]
+	s.lock.Unlock()
+	if ok {
+		t.Fatal("expected the stale peer to be removed")
+	}
+
+	for i := 0; i < _peerMaxNodes; i++ {
+		if code := push(strconv.Itoa(i)); code != http.StatusNoContent {
+			t.Fatalf("expected status %d, got %d", http.StatusNoContent, code)
+		}
+	}
+	if code := push("overflow"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, code)
+	}
+	if code := push("0"); code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, code)
+	}
+}
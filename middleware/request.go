@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"sync"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/kratos/v2/selector"
@@ -21,6 +22,38 @@ type RequestOptions struct {
 	DoneFunc             selector.DoneFunc
 	LastAttempt          bool
 	Values               RequestValues
+	// RetryFilter excludes the backends selected by the previous attempts, it's applied before the Filters
+	RetryFilter selector.NodeFilter
+	// Hedged is shared by the concurrent attempts of a hedged request
+	Hedged *HedgedBackends
+	// HedgeAttempt is the index of the hedged attempt, 0 for the first attempt
+	HedgeAttempt int
+}
+
+// HedgedBackends records the backends selected by the concurrent attempts of a request,
+// the retry filter of every attempt excludes all of them.
+type HedgedBackends struct {
+	lock  sync.Mutex
+	addrs map[string]struct{}
+}
+
+func NewHedgedBackends() *HedgedBackends {
+	return &HedgedBackends{addrs: make(map[string]struct{})}
+}
+
+// Add records the selected backend.
+func (h *HedgedBackends) Add(addr string) {
+	h.lock.Lock()
+	h.addrs[addr] = struct{}{}
+	h.lock.Unlock()
+}
+
+// Has reports whether the backend is selected by any attempt.
+func (h *HedgedBackends) Has(addr string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	_, ok := h.addrs[addr]
+	return ok
 }
 
 type RequestValues interface {
@@ -75,8 +108,8 @@ func NewRequestOptions(c *config.Endpoint) *RequestOptions {
 		DoneFunc: func(ctx context.Context, di selector.DoneInfo) {},
 		Values:   make(requestValues, 5),
 	}
-	o.Filters = []selector.NodeFilter{func(ctx context.Context, nodes []selector.Node) []selector.Node {
-		if len(o.Backends) == 0 {
+	o.RetryFilter = func(ctx context.Context, nodes []selector.Node) []selector.Node {
+		if len(o.Backends) == 0 && o.Hedged == nil {
 			return nodes
 		}
 		selected := make(map[string]struct{}, len(o.Backends))
@@ -85,15 +118,19 @@ func NewRequestOptions(c *config.Endpoint) *RequestOptions {
 		}
 		newNodes := nodes[:0]
 		for _, node := range nodes {
-			if _, ok := selected[node.Address()]; !ok {
-				newNodes = append(newNodes, node)
+			if _, ok := selected[node.Address()]; ok {
+				continue
+			}
+			if o.Hedged != nil && o.Hedged.Has(node.Address()) {
+				continue
 			}
+			newNodes = append(newNodes, node)
 		}
 		if len(newNodes) == 0 {
 			return nodes
 		}
 		return newNodes
-	}}
+	}
 	return o
 }
 
@@ -120,6 +157,20 @@ func EndpointFromContext(ctx context.Context) (*config.Endpoint, bool) {
 	return nil, false
 }
 
+// IsRetryAttempt reports whether the request has been sent to a backend by the previous attempts
+// or by the concurrent hedged attempts.
+func IsRetryAttempt(ctx context.Context) bool {
+	o, ok := ctx.Value(contextKey{}).(*RequestOptions)
+	return ok && (len(o.Backends) > 0 || o.HedgeAttempt > 0)
+}
+
+// HasAttemptFilters reports whether the previous attempts sharing the request options have added
+// their filters, the hedged attempts have their own options and filters.
+func HasAttemptFilters(ctx context.Context) bool {
+	o, ok := ctx.Value(contextKey{}).(*RequestOptions)
+	return ok && len(o.Backends) > 0
+}
+
 // RequestBackendsFromContext returns backend nodes from context.
 func RequestBackendsFromContext(ctx context.Context) ([]string, bool) {
 	o, ok := ctx.Value(contextKey{}).(*RequestOptions)
@@ -138,11 +189,15 @@ func WithRequestBackends(ctx context.Context, backend ...string) context.Context
 	return ctx
 }
 
-// SelectorFiltersFromContext returns selector filter from context.
+// SelectorFiltersFromContext returns the retry filter and the selector filters from context.
 func SelectorFiltersFromContext(ctx context.Context) ([]selector.NodeFilter, bool) {
 	o, ok := ctx.Value(contextKey{}).(*RequestOptions)
 	if ok {
-		return o.Filters, true
+		filters := make([]selector.NodeFilter, 0, len(o.Filters)+1)
+		if o.RetryFilter != nil {
+			filters = append(filters, o.RetryFilter)
+		}
+		return append(filters, o.Filters...), true
 	}
 	return nil, false
 }
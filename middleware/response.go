@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/transport/http/status"
+)
+
+// NewErrorResponse returns a response which rejects the request with the status code,
+// gRPC requests are replied with the corresponding gRPC status in a trailers-only response.
+func NewErrorResponse(req *http.Request, statusCode int, message string) *http.Response {
+	header := make(http.Header)
+	body := []byte(message)
+	if endpoint, ok := EndpointFromContext(req.Context()); ok && endpoint.Protocol == config.Protocol_GRPC {
+		// see https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+		header.Set("Content-Type", "application/grpc")
+		header.Set("Grpc-Status", strconv.Itoa(int(status.ToGRPCCode(statusCode))))
+		header.Set("Grpc-Message", message)
+		statusCode = http.StatusOK
+		body = nil
+	} else if message != "" {
+		header.Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,209 @@
+package singleflight
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/singleflight/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	_defaultMethods     = []string{http.MethodGet, http.MethodHead}
+	_defaultMaxBodySize = int64(4 << 20)
+	// the credentials are always part of the key to never share the responses across the users
+	_credentialHeaders = []string{"Authorization", "Cookie"}
+)
+
+var _metricCollapsedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_collapsed_total",
+	Help:      "The total number of requests served by the response of an identical concurrent request",
+}, []string{"protocol", "method", "path", "service", "basePath"})
+
+func init() {
+	prometheus.MustRegister(_metricCollapsedTotal)
+	middleware.Register("singleflight", Middleware)
+}
+
+// sharedResponse is the response fanned out to the waiters.
+type sharedResponse struct {
+	statusCode int
+	proto      string
+	protoMajor int
+	protoMinor int
+	header     http.Header
+	trailer    http.Header
+	body       []byte
+}
+
+func (s *sharedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(s.statusCode),
+		StatusCode:    s.statusCode,
+		Proto:         s.proto,
+		ProtoMajor:    s.protoMajor,
+		ProtoMinor:    s.protoMinor,
+		Header:        s.header.Clone(),
+		Trailer:       s.trailer.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(s.body)),
+		ContentLength: int64(len(s.body)),
+		Request:       req,
+	}
+}
+
+// private reports whether the response is meant for a single user.
+func private(resp *http.Response) bool {
+	if len(resp.Header.Values("Set-Cookie")) > 0 {
+		return true
+	}
+	for _, v := range resp.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(v, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if strings.EqualFold(name, "private") || strings.EqualFold(name, "no-store") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// share reads the body of the response up to limit, the response is not shared if the body is larger or private.
+func share(resp *http.Response, limit int64) (*sharedResponse, error) {
+	if private(resp) {
+		return nil, nil
+	}
+	var body []byte
+	if resp.Body != nil && resp.Body != http.NoBody {
+		data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if int64(len(data)) > limit {
+			resp.Body = &middleware.MultiReadCloser{Reader: io.MultiReader(bytes.NewReader(data), resp.Body), Closer: resp.Body}
+			return nil, nil
+		}
+		resp.Body.Close()
+		body = data
+	}
+	return &sharedResponse{
+		statusCode: resp.StatusCode,
+		proto:      resp.Proto,
+		protoMajor: resp.ProtoMajor,
+		protoMinor: resp.ProtoMinor,
+		header:     resp.Header,
+		trailer:    resp.Trailer,
+		body:       body,
+	}, nil
+}
+
+func collapsedIncr(req *http.Request) {
+	labels, ok := middleware.MetricsLabelsFromContext(req.Context())
+	if ok {
+		_metricCollapsedTotal.WithLabelValues(labels.Protocol(), labels.Method(), labels.Path(), labels.Service(), labels.BasePath()).Inc()
+	}
+}
+
+// Middleware collapses the concurrent identical idempotent requests into one upstream request.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Singleflight{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	methods := make(map[string]struct{})
+	if len(options.Methods) == 0 {
+		options.Methods = _defaultMethods
+	}
+	for _, m := range options.Methods {
+		methods[strings.ToUpper(m)] = struct{}{}
+	}
+	keyHeaders := make([]string, 0, len(options.KeyHeaders)+len(_credentialHeaders))
+	seen := make(map[string]struct{}, cap(keyHeaders))
+	for _, h := range append(_credentialHeaders, options.KeyHeaders...) {
+		h = textproto.CanonicalMIMEHeaderKey(h)
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		keyHeaders = append(keyHeaders, h)
+	}
+	maxBodySize := _defaultMaxBodySize
+	if options.MaxBodySize > 0 {
+		maxBodySize = options.MaxBodySize
+	}
+	key := func(req *http.Request) string {
+		var b strings.Builder
+		b.WriteString(req.Method)
+		b.WriteByte(' ')
+		b.WriteString(req.Host)
+		b.WriteString(req.URL.EscapedPath())
+		if !options.IgnoreQuery && req.URL.RawQuery != "" {
+			b.WriteByte('?')
+			b.WriteString(req.URL.RawQuery)
+		}
+		for _, h := range keyHeaders {
+			b.WriteByte('\n')
+			b.WriteString(h)
+			b.WriteByte(':')
+			b.WriteString(strings.Join(req.Header.Values(h), ","))
+		}
+		return b.String()
+	}
+	collapsible := func(req *http.Request) bool {
+		if _, ok := methods[req.Method]; !ok {
+			return false
+		}
+		if endpoint, ok := middleware.EndpointFromContext(req.Context()); ok && endpoint.Protocol == config.Protocol_GRPC {
+			return false
+		}
+		return req.Header.Get("Upgrade") == "" && req.ContentLength == 0
+	}
+	g := middleware.NewCallGroup[*sharedResponse]()
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !collapsible(req) {
+				return next.RoundTrip(req)
+			}
+			k := key(req)
+			call, leader := g.Begin(k)
+			if !leader {
+				select {
+				case <-call.Done():
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				}
+				shared := call.Value()
+				if shared == nil {
+					// the leader failed or the response is too large to share
+					return next.RoundTrip(req)
+				}
+				collapsedIncr(req)
+				return shared.response(req), nil
+			}
+			var shared *sharedResponse
+			defer func() { g.End(k, call, shared) }()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if shared, err = share(resp, maxBodySize); err != nil {
+				return nil, err
+			}
+			if shared == nil {
+				return resp, nil
+			}
+			return shared.response(req), nil
+		})
+	}, nil
+}
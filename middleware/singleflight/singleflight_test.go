@@ -0,0 +1,158 @@
+package singleflight
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/singleflight/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestSingleflight(t *testing.T) {
+	type request struct {
+		query  string
+		header http.Header
+	}
+	tests := []struct {
+		name     string
+		options  *v1.Singleflight
+		requests []request
+		header   http.Header
+		calls    int64
+	}{
+		{
+			name:    "key headers",
+			options: &v1.Singleflight{KeyHeaders: []string{"x-tenant"}},
+			requests: []request{
+				{query: "a=1", header: http.Header{"X-Tenant": {"x"}}},
+				{query: "a=2", header: http.Header{"X-Tenant": {"x"}}},
+				{query: "a=1", header: http.Header{"X-Tenant": {"y"}}},
+			},
+			calls: 3,
+		},
+		{
+			name:    "credentials",
+			options: &v1.Singleflight{},
+			requests: []request{
+				{header: http.Header{"Authorization": {"Bearer alice"}}},
+				{header: http.Header{"Authorization": {"Bearer bob"}}},
+			},
+			calls: 2,
+		},
+		{"set cookie", &v1.Singleflight{}, []request{{}}, http.Header{"Set-Cookie": {"session=1"}}, 5},
+		{"private", &v1.Singleflight{}, []request{{}}, http.Header{"Cache-Control": {"max-age=60, private"}}, 5},
+		{"no store", &v1.Singleflight{}, []request{{}}, http.Header{"Cache-Control": {"no-store"}}, 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int64
+			release := make(chan struct{})
+			// the body identifies the request it replies
+			identify := func(req *http.Request) string {
+				return req.URL.RawQuery + " " + req.Header.Get("X-Tenant") + " " + req.Header.Get("Authorization")
+			}
+			next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls.Add(1)
+				<-release
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     test.header.Clone(),
+					Body:       io.NopCloser(strings.NewReader(identify(req))),
+				}, nil
+			})
+			options, err := anypb.New(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := Middleware(&config.Middleware{Options: options})
+			if err != nil {
+				t.Fatal(err)
+			}
+			rt := m(next)
+			var wg sync.WaitGroup
+			for _, r := range test.requests {
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						req, _ := http.NewRequest(http.MethodGet, "http://example.com/items?"+r.query, nil)
+						req.Header = r.header.Clone()
+						if req.Header == nil {
+							req.Header = http.Header{}
+						}
+						resp, err := rt.RoundTrip(req)
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						if body, _ := io.ReadAll(resp.Body); string(body) != identify(req) {
+							t.Errorf("expected the response of %q, got %q", identify(req), body)
+						}
+					}()
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			if n := calls.Load(); n != test.calls {
+				t.Fatalf("expected %d upstream requests, got %d", test.calls, n)
+			}
+		})
+	}
+}
+
+func TestSingleflightNotShared(t *testing.T) {
+	var calls atomic.Int64
+	release := make(chan struct{})
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			<-release
+			return nil, errors.New("upstream failed")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("body"))}, nil
+	})
+	options, err := anypb.New(&v1.Singleflight{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := m(next)
+	leader := make(chan error)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/items", nil)
+		_, err := rt.RoundTrip(req)
+		leader <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	follower := make(chan int)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/items", nil)
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Error(err)
+			follower <- 0
+			return
+		}
+		follower <- resp.StatusCode
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	if err := <-leader; err == nil {
+		t.Fatal("expected the leader to fail")
+	}
+	// the follower sends its own request after the leader failed
+	if code := <-follower; code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("expected the follower to retry on its own, got %d with %d calls", code, calls.Load())
+	}
+}
@@ -0,0 +1,158 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+var _defaultMaxReplayBytes = int64(64 << 10)
+
+var (
+	errBodyNotReplayable = errors.New("request body is not replayable")
+	errBodyReplaced      = errors.New("request body has been replaced by another attempt")
+)
+
+// requestBody provides the request body for each attempt.
+type requestBody interface {
+	// newReader returns a reader from the beginning of the request body.
+	newReader() (io.ReadCloser, error)
+	// replayable reports whether the request body could be read again.
+	replayable() bool
+	// size returns the bytes read from the client.
+	size() int64
+}
+
+func prepareRequestBody(req *http.Request, streaming *config.Streaming) (requestBody, error) {
+	if streaming != nil && streaming.Enabled {
+		limit := streaming.MaxReplayBytes
+		if limit <= 0 {
+			limit = _defaultMaxReplayBytes
+		}
+		return newReplayBody(req.Body, limit), nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	return bufferedBody(body), nil
+}
+
+type bufferedBody []byte
+
+func (b bufferedBody) newReader() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (b bufferedBody) replayable() bool { return true }
+func (b bufferedBody) size() int64      { return int64(len(b)) }
+
+// replayBody forwards the request body as it arrives,
+// and keeps at most limit bytes in memory so that it could be replayed on retries.
+type replayBody struct {
+	readLock sync.Mutex
+	src      io.Reader
+
+	lock       sync.Mutex
+	buf        []byte
+	limit      int64
+	overflow   bool
+	read       int64
+	generation int
+}
+
+func newReplayBody(src io.Reader, limit int64) *replayBody {
+	return &replayBody{
+		src:   src,
+		limit: limit,
+	}
+}
+
+func (b *replayBody) newReader() (io.ReadCloser, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.generation > 0 && b.overflow {
+		return nil, errBodyNotReplayable
+	}
+	b.generation++
+	return &replayReader{body: b, generation: b.generation}, nil
+}
+
+func (b *replayBody) replayable() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return !b.overflow
+}
+
+func (b *replayBody) size() int64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.read
+}
+
+type replayReader struct {
+	body       *replayBody
+	generation int
+	offset     int64
+}
+
+func (r *replayReader) Read(p []byte) (int, error) {
+	b := r.body
+	if n, ok, err := r.readBuffered(p); ok {
+		return n, err
+	}
+
+	b.readLock.Lock()
+	defer b.readLock.Unlock()
+	// the source may have been read by another attempt while waiting for the lock
+	if n, ok, err := r.readBuffered(p); ok {
+		return n, err
+	}
+	n, err := b.src.Read(p)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.read += int64(n)
+	if !b.overflow {
+		if int64(len(b.buf)+n) <= b.limit {
+			b.buf = append(b.buf, p[:n]...)
+		} else {
+			b.overflow = true
+		}
+	}
+	if r.generation != b.generation {
+		return 0, errBodyReplaced
+	}
+	r.offset += int64(n)
+	return n, err
+}
+
+// readBuffered reads from the replay buffer, ok is false if the data should be read from the source.
+func (r *replayReader) readBuffered(p []byte) (n int, ok bool, err error) {
+	b := r.body
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if r.generation != b.generation {
+		return 0, true, errBodyReplaced
+	}
+	if r.offset < int64(len(b.buf)) {
+		n = copy(p, b.buf[r.offset:])
+		r.offset += int64(n)
+		return n, true, nil
+	}
+	if r.offset < b.read {
+		// the data has been read by another attempt but not buffered
+		return 0, true, errBodyNotReplayable
+	}
+	return 0, false, nil
+}
+
+func (r *replayReader) Close() error {
+	return nil
+}
@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReplayBody(t *testing.T) {
+	body := newReplayBody(bytes.NewReader([]byte("hello world")), 8)
+	first, err := body.newReader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(first, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !body.replayable() {
+		t.Fatalf("expected body to be replayable within the limit")
+	}
+
+	second, err := body.newReader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := first.Read(buf); !errors.Is(err, errBodyReplaced) {
+		t.Fatalf("expected replaced error on the previous reader, got: %v", err)
+	}
+	all, err := io.ReadAll(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(all) != "hello world" {
+		t.Fatalf("unexpected replayed body: %q", all)
+	}
+	if body.replayable() {
+		t.Fatalf("expected body not to be replayable over the limit")
+	}
+	if body.size() != int64(len("hello world")) {
+		t.Fatalf("unexpected body size: %d", body.size())
+	}
+	if _, err := body.newReader(); !errors.Is(err, errBodyNotReplayable) {
+		t.Fatalf("expected not replayable error, got: %v", err)
+	}
+}
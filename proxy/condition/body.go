@@ -0,0 +1,151 @@
+package condition
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+const _defaultMaxBodySize = 64 * 1024
+
+// peekBody reads up to limit bytes of the response body and restores it for the client,
+// it reports false if the body is streamed or larger than the limit.
+func peekBody(resp *http.Response, limit int64) ([]byte, bool) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, true
+	}
+	if resp.Request != nil {
+		if e, ok := middleware.EndpointFromContext(resp.Request.Context()); ok && e.Streaming.GetEnabled() {
+			return nil, false
+		}
+	}
+	if resp.ContentLength > limit {
+		return nil, false
+	}
+	buf := &bytes.Buffer{}
+	n, err := io.CopyN(buf, resp.Body, limit+1)
+	if err == io.EOF {
+		resp.Body = middleware.MultiReadCloser{Reader: bytes.NewReader(buf.Bytes()), Closer: resp.Body}
+		return buf.Bytes(), true
+	}
+	resp.Body = middleware.MultiReadCloser{Reader: io.MultiReader(bytes.NewReader(buf.Bytes()[:n]), resp.Body), Closer: resp.Body}
+	return nil, false
+}
+
+type byBody struct {
+	*config.Condition_ByBody
+	regex   *regexp.Regexp
+	path    []string
+	limit   int64
+	matchFn func(string) bool
+}
+
+func (c *byBody) Prepare() error {
+	if c.ByBody == nil {
+		return fmt.Errorf("empty body condition")
+	}
+	c.limit = c.ByBody.MaxBodySize
+	if c.limit <= 0 {
+		c.limit = _defaultMaxBodySize
+	}
+	if c.ByBody.Regex != "" {
+		regex, err := regexp.Compile(c.ByBody.Regex)
+		if err != nil {
+			return err
+		}
+		c.regex = regex
+	}
+	if c.ByBody.JsonPath == "" {
+		if c.regex == nil {
+			return fmt.Errorf("either regex or json_path is required by the body condition")
+		}
+		return nil
+	}
+	c.path = strings.Split(strings.TrimPrefix(c.ByBody.JsonPath, "$."), ".")
+	switch {
+	case c.ByBody.Value != "":
+		values, err := parseAsValues(c.ByBody.Value)
+		if err != nil {
+			return err
+		}
+		c.matchFn = func(v string) bool {
+			for _, value := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		}
+	case c.regex != nil:
+		c.matchFn = c.regex.MatchString
+	default:
+		// the existence of the field
+		c.matchFn = func(string) bool { return true }
+	}
+	return nil
+}
+
+func (c *byBody) Judge(resp *http.Response) bool {
+	body, ok := peekBody(resp, c.limit)
+	if !ok {
+		return false
+	}
+	if c.path == nil {
+		return c.regex.Match(body)
+	}
+	v, ok := lookupJSON(body, c.path)
+	return ok && c.matchFn(v)
+}
+
+// lookupJSON returns the value of the dot separated path in the json document,
+// the objects and arrays are returned as the raw json.
+func lookupJSON(body []byte, path []string) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return "", false
+	}
+	for _, key := range path {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			field, ok := v[key]
+			if !ok {
+				return "", false
+			}
+			doc = field
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", false
+			}
+			doc = v[i]
+		default:
+			return "", false
+		}
+	}
+	switch v := doc.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(raw), true
+	}
+}
@@ -15,6 +15,12 @@ type Condition interface {
 	Judge(*http.Response) bool
 }
 
+// ErrorCondition is the condition judging the failed requests without responses.
+type ErrorCondition interface {
+	Condition
+	JudgeError(*http.Request, error) bool
+}
+
 type byStatusCode struct {
 	*config.Condition_ByStatusCode
 	parsedCodes []int64
@@ -90,29 +96,152 @@ func parseAsStringList(in string) ([]string, error) {
 	return out, nil
 }
 
+// parseAsValues parses the single value or the json list of the values.
+func parseAsValues(in string) ([]string, error) {
+	if strings.HasPrefix(in, "[") {
+		return parseAsStringList(in)
+	}
+	return []string{in}, nil
+}
+
+type byMethod struct {
+	*config.Condition_ByMethod
+	methods map[string]struct{}
+}
+
+func (c *byMethod) Prepare() error {
+	values, err := parseAsValues(c.ByMethod)
+	if err != nil {
+		return err
+	}
+	c.methods = make(map[string]struct{}, len(values))
+	for _, v := range values {
+		c.methods[strings.ToUpper(v)] = struct{}{}
+	}
+	return nil
+}
+
+func (c *byMethod) Judge(resp *http.Response) bool {
+	return resp.Request != nil && c.JudgeError(resp.Request, nil)
+}
+
+func (c *byMethod) JudgeError(req *http.Request, _ error) bool {
+	_, ok := c.methods[req.Method]
+	return ok
+}
+
+type allOf struct {
+	*config.Condition_AllOf
+	conditions []Condition
+}
+
+func (c *allOf) Prepare() (err error) {
+	if len(c.AllOf.GetConditions()) == 0 {
+		return fmt.Errorf("empty all_of condition")
+	}
+	c.conditions, err = ParseConditon(c.AllOf.Conditions...)
+	return err
+}
+
+func (c *allOf) Judge(resp *http.Response) bool {
+	for _, cond := range c.conditions {
+		if !cond.Judge(resp) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *allOf) JudgeError(req *http.Request, err error) bool {
+	for _, cond := range c.conditions {
+		if !judgeError(cond, req, err) {
+			return false
+		}
+	}
+	return true
+}
+
+type not struct {
+	*config.Condition_Not
+	condition Condition
+}
+
+func (c *not) Prepare() (err error) {
+	if c.Not == nil {
+		return fmt.Errorf("empty not condition")
+	}
+	c.condition, err = parseCondition(c.Not)
+	return err
+}
+
+func (c *not) Judge(resp *http.Response) bool {
+	return !c.condition.Judge(resp)
+}
+
+func (c *not) JudgeError(req *http.Request, err error) bool {
+	return !judgeError(c.condition, req, err)
+}
+
+func judgeError(cond Condition, req *http.Request, err error) bool {
+	if c, ok := cond.(ErrorCondition); ok {
+		return c.JudgeError(req, err)
+	}
+	return false
+}
+
+// judgesErrors reports whether the condition tells the classes of the errors.
+func judgesErrors(cond Condition) bool {
+	switch c := cond.(type) {
+	case *byError:
+		return true
+	case *not:
+		return judgesErrors(c.condition)
+	case *allOf:
+		for _, cond := range c.conditions {
+			if judgesErrors(cond) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseCondition(rawCond *config.Condition) (Condition, error) {
+	var cond Condition
+	switch v := rawCond.Condition.(type) {
+	case *config.Condition_ByHeader:
+		cond = &byHeader{Condition_ByHeader: v}
+	case *config.Condition_ByStatusCode:
+		cond = &byStatusCode{Condition_ByStatusCode: v}
+	case *config.Condition_ByGrpcCode:
+		cond = &byGRPCCode{Condition_ByGrpcCode: v}
+	case *config.Condition_ByError:
+		cond = &byError{Condition_ByError: v}
+	case *config.Condition_ByMethod:
+		cond = &byMethod{Condition_ByMethod: v}
+	case *config.Condition_ByBody:
+		cond = &byBody{Condition_ByBody: v}
+	case *config.Condition_AllOf:
+		cond = &allOf{Condition_AllOf: v}
+	case *config.Condition_Not:
+		cond = &not{Condition_Not: v}
+	default:
+		return nil, fmt.Errorf("unknown condition type: %T", v)
+	}
+	if err := cond.Prepare(); err != nil {
+		return nil, err
+	}
+	return cond, nil
+}
+
 func ParseConditon(in ...*config.Condition) ([]Condition, error) {
 	conditions := make([]Condition, 0, len(in))
 	for _, rawCond := range in {
-		switch v := rawCond.Condition.(type) {
-		case *config.Condition_ByHeader:
-			cond := &byHeader{
-				Condition_ByHeader: v,
-			}
-			if err := cond.Prepare(); err != nil {
-				return nil, err
-			}
-			conditions = append(conditions, cond)
-		case *config.Condition_ByStatusCode:
-			cond := &byStatusCode{
-				Condition_ByStatusCode: v,
-			}
-			if err := cond.Prepare(); err != nil {
-				return nil, err
-			}
-			conditions = append(conditions, cond)
-		default:
-			return nil, fmt.Errorf("unknown condition type: %T", v)
+		cond, err := parseCondition(rawCond)
+		if err != nil {
+			return nil, err
 		}
+		conditions = append(conditions, cond)
 	}
 	return conditions, nil
 }
@@ -128,3 +257,24 @@ func JudgeConditons(conditions []Condition, resp *http.Response, onEmpty bool) b
 	}
 	return false
 }
+
+// JudgeErrorConditons judges the failed request by the conditions,
+// onEmpty is returned if none of the conditions tells the classes of the errors.
+func JudgeErrorConditons(conditions []Condition, req *http.Request, err error, onEmpty bool) bool {
+	aware := false
+	for _, cond := range conditions {
+		if judgesErrors(cond) {
+			aware = true
+			break
+		}
+	}
+	if !aware {
+		return onEmpty
+	}
+	for _, cond := range conditions {
+		if judgeError(cond, req, err) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,8 +2,15 @@ package condition
 
 import (
 	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
 	"io"
+	"net"
 	"net/http"
+	"os"
+	"strings"
+	"syscall"
 	"testing"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
@@ -174,3 +181,182 @@ func TestRetryByHeader(t *testing.T) {
 		}
 	}
 }
+
+// trailerBody sets the trailer once the body is read to the end like the http transport.
+type trailerBody struct {
+	io.Reader
+	resp    *http.Response
+	trailer http.Header
+}
+
+func (b *trailerBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		for k, v := range b.trailer {
+			b.resp.Trailer[k] = v
+		}
+	}
+	return n, err
+}
+
+func (b *trailerBody) Close() error { return nil }
+
+func TestRetryByGRPCCode(t *testing.T) {
+	newResp := func(header, trailer http.Header) *http.Response {
+		resp := &http.Response{Header: header, Trailer: http.Header{}, ContentLength: int64(len("payload"))}
+		resp.Body = &trailerBody{Reader: strings.NewReader("payload"), resp: resp, trailer: trailer}
+		return resp
+	}
+	withLength := func(resp *http.Response, length int64) *http.Response {
+		resp.ContentLength = length
+		return resp
+	}
+	testCases := []struct {
+		codes  string
+		resp   *http.Response
+		result bool
+	}{
+		{"UNAVAILABLE", newResp(http.Header{"Grpc-Status": []string{"14"}}, nil), true},
+		{"unavailable", newResp(http.Header{"Grpc-Status": []string{"13"}}, nil), false},
+		{`["DEADLINE_EXCEEDED", "14"]`, newResp(http.Header{"Grpc-Status": []string{"4"}}, nil), true},
+		{"ABORTED-UNAVAILABLE", newResp(http.Header{"Grpc-Status": []string{"13"}}, nil), true},
+		{"ABORTED-UNAVAILABLE", newResp(http.Header{"Grpc-Status": []string{"16"}}, nil), false},
+		{"UNAVAILABLE", newResp(http.Header{"Content-Type": []string{"application/grpc"}}, http.Header{"Grpc-Status": []string{"14"}}), true},
+		{"UNAVAILABLE", newResp(http.Header{"Content-Type": []string{"application/json"}}, http.Header{"Grpc-Status": []string{"14"}}), false},
+		// the trailers of the responses of unknown or large length are not read
+		{"UNAVAILABLE", withLength(newResp(http.Header{"Content-Type": []string{"application/grpc"}}, http.Header{"Grpc-Status": []string{"14"}}), -1), false},
+		{"UNAVAILABLE", withLength(newResp(http.Header{"Content-Type": []string{"application/grpc"}}, http.Header{"Grpc-Status": []string{"14"}}), _maxGRPCBodySize+1), false},
+	}
+	for _, testCase := range testCases {
+		cond := &byGRPCCode{Condition_ByGrpcCode: &config.Condition_ByGrpcCode{ByGrpcCode: testCase.codes}}
+		if err := cond.Prepare(); err != nil {
+			t.Fatalf("prepare error: %v", err)
+		}
+		if result := cond.Judge(testCase.resp); result != testCase.result {
+			t.Errorf("%s, %v: expected %v, got %v", testCase.codes, testCase.resp.Header, testCase.result, result)
+		}
+		body, err := io.ReadAll(testCase.resp.Body)
+		if err != nil || string(body) != "payload" {
+			t.Errorf("expected the body to be restored, got %q: %v", body, err)
+		}
+	}
+	cond := &byGRPCCode{Condition_ByGrpcCode: &config.Condition_ByGrpcCode{ByGrpcCode: "NOT_A_CODE"}}
+	if err := cond.Prepare(); err == nil {
+		t.Errorf("expected error on the unknown code")
+	}
+}
+
+func TestRetryByError(t *testing.T) {
+	req := &http.Request{Method: http.MethodGet}
+	testCases := []struct {
+		classes string
+		err     error
+		result  bool
+	}{
+		{"connect_refused", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, true},
+		{"connect_refused", &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, false},
+		{`["connect_refused", "reset"]`, &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"timeout", &net.DNSError{IsTimeout: true}, true},
+		{"canceled", context.Canceled, true},
+		{"timeout", context.Canceled, false},
+		{"tls", x509.UnknownAuthorityError{}, true},
+		{"any", errors.New("unexpected"), true},
+	}
+	for _, testCase := range testCases {
+		cond := &byError{Condition_ByError: &config.Condition_ByError{ByError: testCase.classes}}
+		if err := cond.Prepare(); err != nil {
+			t.Fatalf("prepare error: %v", err)
+		}
+		if result := cond.JudgeError(req, testCase.err); result != testCase.result {
+			t.Errorf("%s, %v: expected %v, got %v", testCase.classes, testCase.err, testCase.result, result)
+		}
+	}
+	cond := &byError{Condition_ByError: &config.Condition_ByError{ByError: "unknown"}}
+	if err := cond.Prepare(); err == nil {
+		t.Errorf("expected error on the unknown class")
+	}
+}
+
+func TestRetryByBody(t *testing.T) {
+	testCases := []struct {
+		body   *config.ConditionBody
+		data   string
+		result bool
+	}{
+		{&config.ConditionBody{Regex: "server (is )?busy"}, `{"message":"server busy"}`, true},
+		{&config.ConditionBody{Regex: "server (is )?busy"}, `{"message":"ok"}`, false},
+		{&config.ConditionBody{JsonPath: "error.code", Value: `["1001", "1002"]`}, `{"error":{"code":1002}}`, true},
+		{&config.ConditionBody{JsonPath: "$.error.code", Value: "1001"}, `{"error":{"code":1002}}`, false},
+		{&config.ConditionBody{JsonPath: "items.1.retry", Value: "true"}, `{"items":[{},{"retry":true}]}`, true},
+		{&config.ConditionBody{JsonPath: "error.reason", Regex: "^RATE_"}, `{"error":{"reason":"RATE_LIMITED"}}`, true},
+		{&config.ConditionBody{JsonPath: "error"}, `{"error":null}`, true},
+		{&config.ConditionBody{JsonPath: "error"}, `{"data":{}}`, false},
+		{&config.ConditionBody{JsonPath: "error"}, `not json`, false},
+		{&config.ConditionBody{Regex: "busy", MaxBodySize: 4}, `server busy`, false},
+	}
+	for _, testCase := range testCases {
+		cond := &byBody{Condition_ByBody: &config.Condition_ByBody{ByBody: testCase.body}}
+		if err := cond.Prepare(); err != nil {
+			t.Fatalf("prepare error: %v", err)
+		}
+		resp := &http.Response{Body: io.NopCloser(strings.NewReader(testCase.data))}
+		if result := cond.Judge(resp); result != testCase.result {
+			t.Errorf("%v, %s: expected %v, got %v", testCase.body, testCase.data, testCase.result, result)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil || string(body) != testCase.data {
+			t.Errorf("expected the body to be restored, got %q: %v", body, err)
+		}
+	}
+}
+
+func TestComposedConditions(t *testing.T) {
+	conditions, err := ParseConditon(
+		&config.Condition{Condition: &config.Condition_AllOf{AllOf: &config.ConditionConditions{
+			Conditions: []*config.Condition{
+				{Condition: &config.Condition_ByStatusCode{ByStatusCode: "500-599"}},
+				{Condition: &config.Condition_Not{Not: &config.Condition{
+					Condition: &config.Condition_ByMethod{ByMethod: `["post", "patch"]`},
+				}}},
+			},
+		}}},
+		&config.Condition{Condition: &config.Condition_AllOf{AllOf: &config.ConditionConditions{
+			Conditions: []*config.Condition{
+				{Condition: &config.Condition_ByMethod{ByMethod: "GET"}},
+				{Condition: &config.Condition_ByError{ByError: `["connect_refused", "reset"]`}},
+			},
+		}}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := &http.Request{Method: http.MethodGet}
+	post := &http.Request{Method: http.MethodPost}
+	if !JudgeConditons(conditions, &http.Response{StatusCode: 503, Request: get, Body: nopBody}, false) {
+		t.Errorf("expected the failed GET to be retried")
+	}
+	if JudgeConditons(conditions, &http.Response{StatusCode: 503, Request: post, Body: nopBody}, false) {
+		t.Errorf("expected the failed POST not to be retried")
+	}
+	if JudgeConditons(conditions, &http.Response{StatusCode: 200, Request: get, Body: nopBody}, false) {
+		t.Errorf("expected the succeeded GET not to be retried")
+	}
+	refused := &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	if !JudgeErrorConditons(conditions, get, refused, false) {
+		t.Errorf("expected the refused GET to be retried")
+	}
+	if JudgeErrorConditons(conditions, post, refused, false) {
+		t.Errorf("expected the refused POST not to be retried")
+	}
+	if JudgeErrorConditons(conditions, get, context.DeadlineExceeded, true) {
+		t.Errorf("expected the timed out GET not to be retried")
+	}
+	// without the error conditions
+	if !JudgeErrorConditons(conditions[:1], get, context.DeadlineExceeded, true) {
+		t.Errorf("expected onEmpty without the error conditions")
+	}
+	if _, err := ParseConditon(&config.Condition{Condition: &config.Condition_AllOf{AllOf: &config.ConditionConditions{}}}); err == nil {
+		t.Errorf("expected error on the empty all_of")
+	}
+}
@@ -0,0 +1,88 @@
+package condition
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+const (
+	errorConnectRefused = "connect_refused"
+	errorReset          = "reset"
+	errorTimeout        = "timeout"
+	errorTLS            = "tls"
+	errorCanceled       = "canceled"
+	errorAny            = "any"
+)
+
+var _errorClasses = map[string]func(error) bool{
+	errorConnectRefused: func(err error) bool { return errors.Is(err, syscall.ECONNREFUSED) },
+	errorReset:          func(err error) bool { return errors.Is(err, syscall.ECONNRESET) },
+	errorTimeout: func(err error) bool {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return true
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	},
+	errorTLS: func(err error) bool {
+		var (
+			recordErr   tls.RecordHeaderError
+			certErr     *tls.CertificateVerificationError
+			unknownErr  x509.UnknownAuthorityError
+			hostnameErr x509.HostnameError
+			invalidErr  x509.CertificateInvalidError
+		)
+		return errors.As(err, &recordErr) || errors.As(err, &certErr) ||
+			errors.As(err, &unknownErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) ||
+			strings.Contains(err.Error(), "tls: ")
+	},
+	errorCanceled: func(err error) bool { return errors.Is(err, context.Canceled) },
+	errorAny:      func(error) bool { return true },
+}
+
+type byError struct {
+	*config.Condition_ByError
+	classes []func(error) bool
+}
+
+func (c *byError) Prepare() error {
+	values, err := parseAsValues(c.ByError)
+	if err != nil {
+		return err
+	}
+	c.classes = make([]func(error) bool, 0, len(values))
+	for _, v := range values {
+		class, ok := _errorClasses[strings.ToLower(v)]
+		if !ok {
+			return fmt.Errorf("unknown error class: %s", v)
+		}
+		c.classes = append(c.classes, class)
+	}
+	return nil
+}
+
+// Judge is always false since the request with a response didn't fail on the transport.
+func (c *byError) Judge(*http.Response) bool {
+	return false
+}
+
+func (c *byError) JudgeError(_ *http.Request, err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, class := range c.classes {
+		if class(err) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package condition
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+// the max size of the unary responses read for the trailers
+const _maxGRPCBodySize = 64 * 1024
+
+var _grpcCodes = map[string]int64{
+	"OK":                  0,
+	"CANCELED":            1,
+	"CANCELLED":           1,
+	"UNKNOWN":             2,
+	"INVALID_ARGUMENT":    3,
+	"DEADLINE_EXCEEDED":   4,
+	"NOT_FOUND":           5,
+	"ALREADY_EXISTS":      6,
+	"PERMISSION_DENIED":   7,
+	"RESOURCE_EXHAUSTED":  8,
+	"FAILED_PRECONDITION": 9,
+	"ABORTED":             10,
+	"OUT_OF_RANGE":        11,
+	"UNIMPLEMENTED":       12,
+	"INTERNAL":            13,
+	"UNAVAILABLE":         14,
+	"DATA_LOSS":           15,
+	"UNAUTHENTICATED":     16,
+}
+
+func parseGRPCCode(in string) (int64, error) {
+	in = strings.TrimSpace(in)
+	if code, ok := _grpcCodes[strings.ToUpper(in)]; ok {
+		return code, nil
+	}
+	code, err := strconv.ParseInt(in, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid grpc code: %s", in)
+	}
+	return code, nil
+}
+
+type byGRPCCode struct {
+	*config.Condition_ByGrpcCode
+	// the inclusive ranges of the codes
+	ranges [][2]int64
+}
+
+func (c *byGRPCCode) Prepare() error {
+	values, err := parseAsValues(c.ByGrpcCode)
+	if err != nil {
+		return err
+	}
+	c.ranges = make([][2]int64, 0, len(values))
+	for _, v := range values {
+		lo, hi, isRange := strings.Cut(v, "-")
+		from, err := parseGRPCCode(lo)
+		if err != nil {
+			return err
+		}
+		to := from
+		if isRange {
+			if to, err = parseGRPCCode(hi); err != nil {
+				return err
+			}
+		}
+		c.ranges = append(c.ranges, [2]int64{from, to})
+	}
+	return nil
+}
+
+// grpcStatus returns the grpc status of the headers of the trailers-only response, or the trailers.
+// The trailers are read after the body is peeked only if the Content-Length of the response is known
+// and small, otherwise the status is unknown and the condition is not matched.
+func grpcStatus(resp *http.Response) (int64, bool) {
+	if v := resp.Header.Get("Grpc-Status"); v != "" {
+		code, err := strconv.ParseInt(v, 10, 32)
+		return code, err == nil
+	}
+	if v := resp.Trailer.Get("Grpc-Status"); v != "" {
+		code, err := strconv.ParseInt(v, 10, 32)
+		return code, err == nil
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/grpc") {
+		return 0, false
+	}
+	if resp.ContentLength < 0 || resp.ContentLength > _maxGRPCBodySize {
+		return 0, false
+	}
+	if _, ok := peekBody(resp, _maxGRPCBodySize); !ok {
+		return 0, false
+	}
+	v := resp.Trailer.Get("Grpc-Status")
+	if v == "" {
+		return 0, false
+	}
+	code, err := strconv.ParseInt(v, 10, 32)
+	return code, err == nil
+}
+
+func (c *byGRPCCode) Judge(resp *http.Response) bool {
+	code, ok := grpcStatus(resp)
+	if !ok {
+		return false
+	}
+	for _, r := range c.ranges {
+		if code >= r[0] && code <= r[1] {
+			return true
+		}
+	}
+	return false
+}
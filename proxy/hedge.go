@@ -0,0 +1,242 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const _defaultHedgingAttempts = 2
+
+const (
+	winnerPrimary = "primary"
+	winnerHedge   = "hedge"
+	winnerNone    = "none"
+)
+
+var _metricHedgeTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_hedge_total",
+	Help:      "The total number of hedged requests by the winner attempt",
+}, []string{"protocol", "method", "path", "service", "basePath", "winner"})
+
+func init() {
+	prometheus.MustRegister(_metricHedgeTotal)
+}
+
+var _idempotentMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodOptions: {},
+	http.MethodTrace:   {},
+	http.MethodPut:     {},
+	http.MethodDelete:  {},
+}
+
+type hedgingPolicy struct {
+	delay         time.Duration
+	maxAttempts   int
+	nonIdempotent bool
+}
+
+func prepareHedgingPolicy(e *config.Endpoint) *hedgingPolicy {
+	h := e.GetRetry().GetHedging()
+	if h == nil {
+		return nil
+	}
+	policy := &hedgingPolicy{
+		delay:         h.Delay.AsDuration(),
+		maxAttempts:   int(h.MaxAttempts),
+		nonIdempotent: h.NonIdempotent,
+	}
+	if policy.maxAttempts == 0 {
+		policy.maxAttempts = _defaultHedgingAttempts
+	}
+	return policy
+}
+
+// applicable reports whether the request can be sent in parallel,
+// the streaming bodies and connections can only be consumed once.
+func (h *hedgingPolicy) applicable(e *config.Endpoint, req *http.Request, body requestBody) bool {
+	if _, ok := body.(bufferedBody); !ok {
+		return false
+	}
+	if isUpgradeRequest(req) || isStreamingGRPC(e, req) {
+		return false
+	}
+	if _, ok := _idempotentMethods[req.Method]; !ok && !h.nonIdempotent {
+		return false
+	}
+	return true
+}
+
+type hedgeAttempt struct {
+	index  int
+	opts   *middleware.RequestOptions
+	resp   *http.Response
+	err    error
+	cancel context.CancelFunc
+}
+
+// close releases the attempt which is not replied to the client.
+func (a *hedgeAttempt) close(ctx context.Context, err error) {
+	if a.resp != nil {
+		a.resp.Body.Close()
+		a.opts.DoneFunc(ctx, selector.DoneInfo{Err: err})
+	}
+	a.cancel()
+}
+
+type hedgeHooks struct {
+	allowRetry  func(req *http.Request, i int) (func(), bool)
+	markSuccess func(req *http.Request, i int)
+	markFailed  func(req *http.Request, i int, err error)
+}
+
+// hedge sends the next attempt to another node once the delay elapsed or the previous attempts failed,
+// the first good response wins and the other attempts are canceled. The returned cancel releases the response.
+func (p *Proxy) hedge(ctx context.Context, req *http.Request, reqOpts *middleware.RequestOptions, tripper http.RoundTripper, body requestBody,
+	strategy *retryStrategy, policy *hedgingPolicy, hooks *hedgeHooks) (*http.Response, context.CancelFunc, error) {
+	labels := middleware.NewMetricsLabels(reqOpts.Endpoint)
+	results := make(chan *hedgeAttempt, policy.maxAttempts)
+	hedged := middleware.NewHedgedBackends()
+	cancels := make([]context.CancelFunc, 0, policy.maxAttempts)
+	launch := func(i int) error {
+		// every attempt has its own options and retry filter, the tried backends are shared by the hedged backends
+		opts := middleware.NewRequestOptions(reqOpts.Endpoint)
+		opts.Filters = append(opts.Filters, reqOpts.Filters...)
+		opts.Hedged = hedged
+		opts.HedgeAttempt = i
+		opts.LastAttempt = i+1 >= policy.maxAttempts
+		tryCtx, cancel := p.Interceptors.prepareAttemptTimeoutContext(middleware.NewRequestContext(ctx, opts), req, strategy.perTryTimeout)
+		reader, err := body.newReader()
+		if err != nil {
+			cancel()
+			return err
+		}
+		cancels = append(cancels, cancel)
+		attemptReq := req.Clone(tryCtx)
+		attemptReq.Body = reader
+		go func() {
+			resp, err := tripper.RoundTrip(attemptReq)
+			results <- &hedgeAttempt{index: i, opts: opts, resp: resp, err: err, cancel: cancel}
+		}()
+		return nil
+	}
+	var launched, pending int
+	var ends []func()
+	defer func() {
+		for _, end := range ends {
+			end()
+		}
+	}()
+	next := func() bool {
+		if launched >= policy.maxAttempts {
+			return false
+		}
+		if launched > 0 {
+			endRetry, ok := hooks.allowRetry(req, launched)
+			if !ok {
+				return false
+			}
+			ends = append(ends, endRetry)
+		}
+		if err := launch(launched); err != nil {
+			hooks.markFailed(req, launched, err)
+			return false
+		}
+		launched++
+		pending++
+		return true
+	}
+	merge := func(a *hedgeAttempt) {
+		reqOpts.Backends = append(reqOpts.Backends, a.opts.Backends...)
+		reqOpts.UpstreamStatusCode = append(reqOpts.UpstreamStatusCode, a.opts.UpstreamStatusCode...)
+		reqOpts.UpstreamResponseTime = append(reqOpts.UpstreamResponseTime, a.opts.UpstreamResponseTime...)
+		for k, v := range a.opts.Metadata {
+			reqOpts.Metadata[k] = v
+		}
+	}
+	if !next() {
+		return nil, func() {}, errors.New("failed to send the request")
+	}
+	timer := time.NewTimer(policy.delay)
+	defer timer.Stop()
+	var last *hedgeAttempt
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if next() {
+				timer.Reset(policy.delay)
+			}
+		case a := <-results:
+			pending--
+			merge(a)
+			if a.err == nil && !judgeRetryRequired(strategy.conditions, a.resp) {
+				hooks.markSuccess(req, a.index)
+				if last != nil {
+					last.close(ctx, context.Canceled)
+				}
+				for i, cancel := range cancels {
+					if i != a.index {
+						cancel()
+					}
+				}
+				go func(pending int) {
+					for ; pending > 0; pending-- {
+						(<-results).close(ctx, context.Canceled)
+					}
+				}(pending)
+				reqOpts.CurrentNode = a.opts.CurrentNode
+				reqOpts.DoneFunc = a.opts.DoneFunc
+				reqOpts.LastAttempt = true
+				hedgeIncr(req, labels, launched, a.index)
+				return a.resp, a.cancel, nil
+			}
+			retryable := true
+			if a.err != nil {
+				hooks.markFailed(req, a.index, a.err)
+				log.Errorf("Hedged attempt at [%d/%d], failed to handle request: %s: %+v", a.index+1, policy.maxAttempts, req.URL.String(), a.err)
+				retryable = judgeRetryOnError(strategy.conditions, req, a.err)
+			} else {
+				hooks.markFailed(req, a.index, errors.New("assertion failed"))
+			}
+			if last != nil {
+				last.close(ctx, errors.New("assertion failed"))
+			}
+			last = a
+			// retry the failed attempt without waiting for the delay
+			if retryable && next() {
+				timer.Reset(policy.delay)
+			}
+		}
+	}
+	// all the attempts failed, reply the last one
+	hedgeIncr(req, labels, launched, -1)
+	reqOpts.CurrentNode = last.opts.CurrentNode
+	reqOpts.DoneFunc = last.opts.DoneFunc
+	reqOpts.LastAttempt = true
+	return last.resp, last.cancel, last.err
+}
+
+func hedgeIncr(req *http.Request, labels middleware.MetricsLabels, launched, winner int) {
+	if launched <= 1 {
+		return
+	}
+	result := winnerNone
+	switch {
+	case winner == 0:
+		result = winnerPrimary
+	case winner > 0:
+		result = winnerHedge
+	}
+	_metricHedgeTotal.WithLabelValues(labels.Protocol(), req.Method, labels.Path(), labels.Service(), labels.BasePath(), result).Inc()
+}
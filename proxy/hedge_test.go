@@ -0,0 +1,159 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	ratelimitv1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	_ "github.com/go-kratos/gateway/middleware/ratelimit"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestHedging(t *testing.T) {
+	c := &config.Gateway{
+		Name: "Test",
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/hedged",
+			Timeout:  durationpb.New(5 * time.Second),
+			Retry: &config.Retry{
+				Hedging: &config.Hedging{Delay: durationpb.New(20 * time.Millisecond), MaxAttempts: 2},
+			},
+		}},
+	}
+	nodes := []selector.Node{
+		selector.NewNode("http", "slow", &registry.ServiceInstance{}),
+		selector.NewNode("http", "fast", &registry.ServiceInstance{}),
+	}
+	var attempts, canceled atomic.Int64
+	clientFactory := func(*client.BuildContext, *config.Endpoint) (client.Client, error) {
+		return RoundTripperCloserFunc(func(req *http.Request) (*http.Response, error) {
+			attempts.Add(1)
+			opts, _ := middleware.FromRequestContext(req.Context())
+			candidates := append([]selector.Node(nil), nodes...)
+			filters, _ := middleware.SelectorFiltersFromContext(req.Context())
+			for _, f := range filters {
+				candidates = f(req.Context(), candidates)
+			}
+			// pick the first candidate like the client
+			addr := candidates[0].Address()
+			opts.Backends = append(opts.Backends, addr)
+			if opts.Hedged != nil {
+				opts.Hedged.Add(addr)
+			}
+			if addr == "slow" {
+				select {
+				case <-time.After(time.Second):
+				case <-req.Context().Done():
+					canceled.Add(1)
+					return nil, req.Context().Err()
+				}
+			}
+			body, _ := io.ReadAll(req.Body)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(addr + ":" + string(body)))}, nil
+		}), nil
+	}
+	middlewareFactory := func(c *config.Middleware) (middleware.MiddlewareV2, error) {
+		return nil, middleware.ErrNotFound
+	}
+	p, err := New(clientFactory, middlewareFactory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(client.NewBuildContext(c), c); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	w := newResponseWriter()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/hedged", strings.NewReader("body")))
+	if w.statusCode != http.StatusOK || w.body.String() != "fast:body" {
+		t.Fatalf("expected the response of the hedged attempt, got %d %s", w.statusCode, w.body.String())
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the hedged attempt not to wait for the slow one, took %s", elapsed)
+	}
+	deadline := time.Now().Add(time.Second)
+	for canceled.Load() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the slow attempt to be canceled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the non-idempotent requests are not hedged
+	attempts.Store(0)
+	w = newResponseWriter()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/hedged", strings.NewReader("body")).WithContext(context.Background()))
+	if w.statusCode != http.StatusOK || w.body.String() != "slow:body" || attempts.Load() != 1 {
+		t.Fatalf("expected a single attempt to the slow node, got %d %s %d", w.statusCode, w.body.String(), attempts.Load())
+	}
+}
+
+func TestHedgingRateLimit(t *testing.T) {
+	limit, err := anypb.New(&ratelimitv1.RateLimit{Limits: []*ratelimitv1.Limit{{Requests: 1, Period: durationpb.New(time.Minute)}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &config.Gateway{
+		Name: "Test",
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/hedged",
+			Timeout:  durationpb.New(5 * time.Second),
+			Retry: &config.Retry{
+				Hedging: &config.Hedging{Delay: durationpb.New(20 * time.Millisecond), MaxAttempts: 2},
+			},
+			Middlewares: []*config.Middleware{{Name: "ratelimit", Options: limit}},
+		}},
+	}
+	var attempts atomic.Int64
+	clientFactory := func(*client.BuildContext, *config.Endpoint) (client.Client, error) {
+		return RoundTripperCloserFunc(func(req *http.Request) (*http.Response, error) {
+			opts, _ := middleware.FromRequestContext(req.Context())
+			addr := "slow"
+			if attempts.Add(1) > 1 {
+				addr = "fast"
+			}
+			opts.Backends = append(opts.Backends, addr)
+			if addr == "slow" {
+				select {
+				case <-time.After(time.Second):
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				}
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(addr))}, nil
+		}), nil
+	}
+	p, err := New(clientFactory, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(client.NewBuildContext(c), c); err != nil {
+		t.Fatal(err)
+	}
+	// the hedged attempt is not counted against the limit again
+	w := newResponseWriter()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hedged", nil))
+	if w.statusCode != http.StatusOK || w.body.String() != "fast" || attempts.Load() != 2 {
+		t.Fatalf("expected the response of the hedged attempt, got %d %s %d", w.statusCode, w.body.String(), attempts.Load())
+	}
+	w = newResponseWriter()
+	p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hedged", nil))
+	if w.statusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the next request to be limited, got %d", w.statusCode)
+	}
+}
@@ -1,7 +1,6 @@
 package proxy
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -17,7 +16,6 @@ import (
 	"time"
 
 	"github.com/go-kratos/aegis/circuitbreaker"
-	"github.com/go-kratos/aegis/circuitbreaker/sre"
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/gateway/client"
 	"github.com/go-kratos/gateway/middleware"
@@ -243,7 +241,7 @@ func (p *Proxy) buildEndpoint(buildCtx *client.BuildContext, e *config.Endpoint,
 	}
 	labels := middleware.NewMetricsLabels(e)
 	markSuccessStat, markFailedStat, markBreakerStat := splitRetryMetricsHandler(e)
-	retryBreaker := sre.NewBreaker(sre.WithSuccess(0.8), sre.WithRequest(10))
+	retryBreaker := newRetryBreaker(e)
 	markSuccess := func(req *http.Request, i int) {
 		markSuccessStat(req, i)
 		if i > 0 {
@@ -259,46 +257,94 @@ func (p *Proxy) buildEndpoint(buildCtx *client.BuildContext, e *config.Endpoint,
 	markBreaker := func(req *http.Request, i int) {
 		markBreakerStat(req, i)
 	}
+	// allowRetry reports whether the retry is allowed, the returned function ends the retry in the budget.
+	allowRetry := func(req *http.Request, i int) (func(), bool) {
+		if !retryFeature.Enabled() {
+			return nil, false
+		}
+		if err := retryBreaker.Allow(); err != nil {
+			if errors.Is(err, circuitbreaker.ErrNotAllowed) {
+				markBreaker(req, i)
+			} else {
+				markFailed(req, i, err)
+			}
+			return nil, false
+		}
+		end, ok := retryStrategy.budget.allow()
+		if !ok {
+			retryStateIncr(req, labels, "budget")
+			return nil, false
+		}
+		return end, true
+	}
+	hedgingPolicy := prepareHedgingPolicy(e)
+	hooks := &hedgeHooks{allowRetry: allowRetry, markSuccess: markSuccess, markFailed: markFailed}
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		startTime := time.Now()
 		setXFFHeader(req)
 
 		reqOpts := middleware.NewRequestOptions(e)
+		if retryStrategy.priorityFilter != nil {
+			reqOpts.Filters = append(reqOpts.Filters, retryStrategy.priorityFilter)
+		}
 		ctx := middleware.NewRequestContext(req.Context(), reqOpts)
 		ctx, cancel := context.WithTimeout(ctx, retryStrategy.timeout)
 		defer cancel()
+		defer retryStrategy.budget.begin()()
 		defer func() {
 			requestsDurationObserve(req, labels, time.Since(startTime).Seconds())
 		}()
 
-		body, err := io.ReadAll(req.Body)
+		body, err := prepareRequestBody(req, e.Streaming)
 		if err != nil {
 			writeError(w, req, err, labels)
 			return
 		}
-		receivedBytesAdd(req, labels, int64(len(body)))
-		req.GetBody = func() (io.ReadCloser, error) {
-			reader := bytes.NewReader(body)
-			return io.NopCloser(reader), nil
+		defer func() {
+			receivedBytesAdd(req, labels, body.size())
+		}()
+		req.GetBody = body.newReader
+		streamingGRPC := isStreamingGRPC(e, req)
+		if streamingGRPC {
+			// streaming calls are bounded by the endpoint timeout instead of the server timeouts
+			rc := http.NewResponseController(w)
+			_ = rc.SetReadDeadline(time.Time{})
+			_ = rc.SetWriteDeadline(time.Time{})
+		}
+		if e.Streaming != nil && e.Streaming.Enabled && req.ProtoMajor == 1 {
+			// allow the response to be written before the request body is fully read
+			_ = http.NewResponseController(w).EnableFullDuplex()
 		}
 
 		var resp *http.Response
-		for i := 0; i < retryStrategy.attempts; i++ {
+		attempts := retryStrategy.attempts
+		if hedgingPolicy != nil && hedgingPolicy.applicable(e, req, body) {
+			var cancel context.CancelFunc
+			resp, cancel, err = p.hedge(ctx, req, reqOpts, tripper, body, retryStrategy, hedgingPolicy, hooks)
+			defer cancel()
+			attempts = 0
+		}
+		var pushback time.Duration
+		for i := 0; i < attempts; i++ {
 			if i > 0 {
-				if !retryFeature.Enabled() {
+				if !body.replayable() {
+					// the request body exceeds the replay buffer
 					break
 				}
-				if err := retryBreaker.Allow(); err != nil {
-					if errors.Is(err, circuitbreaker.ErrNotAllowed) {
-						markBreaker(req, i)
-					} else {
-						markFailed(req, i, err)
-					}
+				endRetry, ok := allowRetry(req, i)
+				if !ok {
 					break
 				}
+				defer endRetry()
+				if !sleepContext(ctx, max(retryStrategy.backoff.interval(i), pushback)) {
+					err = ctx.Err()
+					markFailed(req, i, err)
+					break
+				}
+				pushback = 0
 			}
 
-			if (i + 1) >= retryStrategy.attempts {
+			if (i + 1) >= attempts {
 				reqOpts.LastAttempt = true
 			}
 			// canceled or deadline exceeded
@@ -308,12 +354,17 @@ func (p *Proxy) buildEndpoint(buildCtx *client.BuildContext, e *config.Endpoint,
 			}
 			tryCtx, cancel := p.Interceptors.prepareAttemptTimeoutContext(ctx, req, retryStrategy.perTryTimeout)
 			defer cancel()
-			reader := bytes.NewReader(body)
-			req.Body = io.NopCloser(reader)
+			if req.Body, err = body.newReader(); err != nil {
+				markFailed(req, i, err)
+				break
+			}
 			resp, err = tripper.RoundTrip(req.Clone(tryCtx))
 			if err != nil {
 				markFailed(req, i, err)
-				log.Errorf("Attempt at [%d/%d], failed to handle request: %s: %+v", i+1, retryStrategy.attempts, req.URL.String(), err)
+				log.Errorf("Attempt at [%d/%d], failed to handle request: %s: %+v", i+1, attempts, req.URL.String(), err)
+				if !judgeRetryOnError(retryStrategy.conditions, req, err) {
+					break
+				}
 				continue
 			}
 			if !judgeRetryRequired(retryStrategy.conditions, resp) {
@@ -322,6 +373,16 @@ func (p *Proxy) buildEndpoint(buildCtx *client.BuildContext, e *config.Endpoint,
 				break
 			}
 			markFailed(req, i, errors.New("assertion failed"))
+			if !body.replayable() || (i+1) >= attempts {
+				// no more attempts, respond with the current response
+				break
+			}
+			delay, ok := retryPushback(resp)
+			if deadline, hasDeadline := ctx.Deadline(); !ok || (hasDeadline && time.Until(deadline) <= delay) {
+				// the upstream asks not to retry before the deadline
+				break
+			}
+			pushback = delay
 			resp.Body.Close()
 			// continue the retry loop
 		}
@@ -330,6 +391,29 @@ func (p *Proxy) buildEndpoint(buildCtx *client.BuildContext, e *config.Endpoint,
 			return
 		}
 
+		if resp.StatusCode == http.StatusSwitchingProtocols && isUpgradeRequest(req) {
+			upgraded, hijacked, err := hijackUpgrade(w, req, resp)
+			if err != nil {
+				reqOpts.DoneFunc(ctx, selector.DoneInfo{Err: err})
+				if hijacked {
+					// the connection is taken over, the error response could not be written
+					log.Errorf("Failed to switch protocols: [%s] %s %s %+v", e.Protocol, e.Method, e.Path, err)
+					return
+				}
+				writeError(w, req, err, labels)
+				return
+			}
+			sent, received, err := upgraded.serve(req.Context())
+			sentBytesAdd(req, labels, sent)
+			receivedBytesAdd(req, labels, received)
+			if err != nil {
+				log.Errorf("Failed to proxy upgraded connection: [%s] %s %s %+v", e.Protocol, e.Method, e.Path, err)
+			}
+			reqOpts.DoneFunc(ctx, selector.DoneInfo{Err: err})
+			requestsTotalIncr(req, labels, resp.StatusCode)
+			return
+		}
+
 		headers := w.Header()
 		for k, v := range resp.Header {
 			headers[k] = v
@@ -355,6 +439,8 @@ func (p *Proxy) buildEndpoint(buildCtx *client.BuildContext, e *config.Endpoint,
 			copyFunc := io.Copy
 			if isNoBufferingResponse(resp) {
 				copyFunc = copyNoBuffering(w)
+			} else if streamingGRPC {
+				copyFunc = copyStreaming(w)
 			}
 			sent, err := copyFunc(w, resp.Body)
 			if err != nil {
@@ -2,23 +2,43 @@ package proxy
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/aegis/circuitbreaker/sre"
 	"github.com/go-kratos/feature"
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/client"
 	"github.com/go-kratos/gateway/proxy/condition"
+	"github.com/go-kratos/kratos/v2/selector"
 )
 
 var (
 	retryFeature = feature.MustRegister("gw:Retry", true)
 )
 
+var (
+	_defaultBackoffBaseInterval = 25 * time.Millisecond
+	_defaultMinRetriesPerSecond = uint32(10)
+	_defaultRetrySuccess        = 0.8
+	_defaultRetryRequest        = int64(10)
+)
+
 type retryStrategy struct {
-	attempts      int
-	timeout       time.Duration
-	perTryTimeout time.Duration
-	conditions    []condition.Condition
+	attempts       int
+	timeout        time.Duration
+	perTryTimeout  time.Duration
+	conditions     []condition.Condition
+	priorityFilter selector.NodeFilter
+	backoff        *backoffPolicy
+	budget         *retryBudget
 }
 
 func calcTimeout(endpoint *config.Endpoint) time.Duration {
@@ -66,9 +86,192 @@ func prepareRetryStrategy(e *config.Endpoint) (*retryStrategy, error) {
 		return nil, err
 	}
 	strategy.conditions = conditions
+	if err := validatePriorities(e); err != nil {
+		return nil, err
+	}
+	if e.Retry != nil && len(e.Retry.Priorities) > 1 {
+		strategy.priorityFilter = client.NewPriorityFilter(e.Retry.Priorities)
+	}
+	strategy.backoff = newBackoffPolicy(e.GetRetry().GetBackoff())
+	strategy.budget = newRetryBudget(e.GetRetry().GetBudget())
 	return strategy, nil
 }
 
+// validatePriorities rejects the backends of the unknown priority groups,
+// which would be treated as the first group silently.
+func validatePriorities(e *config.Endpoint) error {
+	priorities := make(map[string]struct{}, len(e.GetRetry().GetPriorities()))
+	for _, p := range e.GetRetry().GetPriorities() {
+		priorities[p] = struct{}{}
+	}
+	for _, b := range e.Backends {
+		if b.Priority == "" {
+			continue
+		}
+		if _, ok := priorities[b.Priority]; !ok {
+			return fmt.Errorf("priority %q of backend %s is not in the retry priorities", b.Priority, b.Target)
+		}
+	}
+	return nil
+}
+
+func newRetryBreaker(e *config.Endpoint) circuitbreaker.CircuitBreaker {
+	c := e.GetRetry().GetBreaker()
+	opts := []sre.Option{sre.WithSuccess(_defaultRetrySuccess), sre.WithRequest(_defaultRetryRequest)}
+	if c == nil {
+		return sre.NewBreaker(opts...)
+	}
+	if c.Success > 0 {
+		opts = append(opts, sre.WithSuccess(c.Success))
+	}
+	if c.Request > 0 {
+		opts = append(opts, sre.WithRequest(int64(c.Request)))
+	}
+	if c.Bucket > 0 {
+		opts = append(opts, sre.WithBucket(int(c.Bucket)))
+	}
+	if c.Window != nil && c.Window.AsDuration() > 0 {
+		opts = append(opts, sre.WithWindow(c.Window.AsDuration()))
+	}
+	return sre.NewBreaker(opts...)
+}
+
+type backoffPolicy struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+}
+
+func newBackoffPolicy(c *config.RetryBackoff) *backoffPolicy {
+	if c == nil {
+		return nil
+	}
+	b := &backoffPolicy{base: _defaultBackoffBaseInterval, jitter: math.Min(math.Max(c.Jitter, 0), 1)}
+	if c.BaseInterval != nil && c.BaseInterval.AsDuration() > 0 {
+		b.base = c.BaseInterval.AsDuration()
+	}
+	b.max = 10 * b.base
+	if c.MaxInterval != nil && c.MaxInterval.AsDuration() > 0 {
+		b.max = max(c.MaxInterval.AsDuration(), b.base)
+	}
+	return b
+}
+
+// interval returns the exponential back-off before the retry of the attempt.
+func (b *backoffPolicy) interval(attempt int) time.Duration {
+	if b == nil || attempt <= 0 {
+		return 0
+	}
+	d := b.max
+	if shift := attempt - 1; shift < 32 && b.base<<shift < b.max {
+		d = b.base << shift
+	}
+	if b.jitter > 0 {
+		d = time.Duration(float64(d) * (1 + b.jitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// retryBudget allows the in-flight retries up to the percentage of the active requests,
+// and a minimum number of retries per second regardless of the percentage.
+type retryBudget struct {
+	percent      float64
+	minPerSecond int64
+	active       atomic.Int64
+	retries      atomic.Int64
+
+	lock   sync.Mutex
+	second int64
+	count  int64
+}
+
+func newRetryBudget(c *config.RetryBudget) *retryBudget {
+	if c == nil {
+		return nil
+	}
+	b := &retryBudget{percent: c.BudgetPercent, minPerSecond: int64(c.MinRetriesPerSecond)}
+	if c.MinRetriesPerSecond == 0 {
+		b.minPerSecond = int64(_defaultMinRetriesPerSecond)
+	}
+	return b
+}
+
+// begin marks an active request, the returned function ends it.
+func (b *retryBudget) begin() func() {
+	if b == nil {
+		return func() {}
+	}
+	b.active.Add(1)
+	return func() { b.active.Add(-1) }
+}
+
+// allow reports whether a retry is allowed, the retry should be ended by the returned function.
+func (b *retryBudget) allow() (func(), bool) {
+	if b == nil {
+		return func() {}, true
+	}
+	retries := b.retries.Add(1)
+	end := func() { b.retries.Add(-1) }
+	if float64(retries) <= float64(b.active.Load())*b.percent/100 {
+		return end, true
+	}
+	now := time.Now().Unix()
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.second != now {
+		b.second, b.count = now, 0
+	}
+	if b.count < b.minPerSecond {
+		b.count++
+		return end, true
+	}
+	end()
+	return nil, false
+}
+
+// retryPushback returns the delay before the retry asked by the upstream,
+// ok is false if the upstream asks not to retry.
+func retryPushback(resp *http.Response) (delay time.Duration, ok bool) {
+	// see https://github.com/grpc/proposal/blob/master/A6-client-retries.md#pushback
+	pushback := resp.Trailer.Get("Grpc-Retry-Pushback-Ms")
+	if pushback == "" {
+		pushback = resp.Header.Get("Grpc-Retry-Pushback-Ms")
+	}
+	if pushback != "" {
+		ms, err := strconv.ParseInt(pushback, 10, 64)
+		if err != nil || ms < 0 {
+			return 0, false
+		}
+		return time.Duration(ms) * time.Millisecond, true
+	}
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, true
+	}
+	if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, true
+}
+
+// sleepContext waits for the duration, it returns false once the context is done.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func parseRetryConditon(endpoint *config.Endpoint) ([]condition.Condition, error) {
 	if endpoint.Retry == nil {
 		return []condition.Condition{}, nil
@@ -80,6 +283,12 @@ func judgeRetryRequired(conditions []condition.Condition, resp *http.Response) b
 	return condition.JudgeConditons(conditions, resp, false)
 }
 
+// judgeRetryOnError reports whether the failed request is retryable,
+// all the errors are retried unless the conditions tell the classes of the errors.
+func judgeRetryOnError(conditions []condition.Condition, req *http.Request, err error) bool {
+	return condition.JudgeErrorConditons(conditions, req, err, true)
+}
+
 func defaultAttemptTimeoutContext(ctx context.Context, _ *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, timeout)
 }
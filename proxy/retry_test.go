@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -71,3 +72,95 @@ func TestCalcTimeout(t *testing.T) {
 		}
 	}
 }
+
+func TestBackoffInterval(t *testing.T) {
+	b := newBackoffPolicy(&config.RetryBackoff{BaseInterval: durationpb.New(10 * time.Millisecond), MaxInterval: durationpb.New(50 * time.Millisecond)})
+	expected := []time.Duration{0, 10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond}
+	for i, e := range expected {
+		if d := b.interval(i); d != e {
+			t.Errorf("interval(%d) = %s, want %s", i, d, e)
+		}
+	}
+	b.jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := b.interval(1); d < 5*time.Millisecond || d > 15*time.Millisecond {
+			t.Fatalf("expected the interval within the jitter, got %s", d)
+		}
+	}
+	if d := (*backoffPolicy)(nil).interval(3); d != 0 {
+		t.Errorf("expected no back-off without the policy, got %s", d)
+	}
+}
+
+func TestRetryPushback(t *testing.T) {
+	testCases := []struct {
+		header  http.Header
+		trailer http.Header
+		delay   time.Duration
+		ok      bool
+	}{
+		{header: http.Header{}, ok: true},
+		{header: http.Header{"Retry-After": {"2"}}, delay: 2 * time.Second, ok: true},
+		{header: http.Header{"Grpc-Retry-Pushback-Ms": {"150"}}, delay: 150 * time.Millisecond, ok: true},
+		{header: http.Header{}, trailer: http.Header{"Grpc-Retry-Pushback-Ms": {"-1"}}, ok: false},
+		{header: http.Header{"Grpc-Retry-Pushback-Ms": {"invalid"}}, ok: false},
+	}
+	for _, tc := range testCases {
+		delay, ok := retryPushback(&http.Response{Header: tc.header, Trailer: tc.trailer})
+		if delay != tc.delay || ok != tc.ok {
+			t.Errorf("retryPushback(%v, %v) = %s %v, want %s %v", tc.header, tc.trailer, delay, ok, tc.delay, tc.ok)
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	b := newRetryBudget(&config.RetryBudget{BudgetPercent: 20, MinRetriesPerSecond: 1})
+	for i := 0; i < 10; i++ {
+		b.begin()
+	}
+	var ends []func()
+	for i := 0; i < 2; i++ {
+		end, ok := b.allow()
+		if !ok {
+			t.Fatalf("expected the retry %d within the budget", i)
+		}
+		ends = append(ends, end)
+	}
+	// the min retries per second
+	end, ok := b.allow()
+	if !ok {
+		t.Fatal("expected the retry to be allowed by the min retries per second")
+	}
+	ends = append(ends, end)
+	if _, ok := b.allow(); ok {
+		t.Fatal("expected the retry to be rejected out of the budget")
+	}
+	for _, end := range ends {
+		end()
+	}
+	if _, ok := b.allow(); !ok {
+		t.Fatal("expected the retry to be allowed after the retries ended")
+	}
+}
+
+func TestPrepareRetryPriorities(t *testing.T) {
+	testCases := []struct {
+		priorities []string
+		priority   string
+		ok         bool
+	}{
+		{priorities: []string{"primary", "backup"}, priority: "backup", ok: true},
+		{priorities: []string{"primary", "backup"}, priority: "", ok: true},
+		{priorities: []string{"primary", "backup"}, priority: "bakup", ok: false},
+		{priority: "backup", ok: false},
+	}
+	for _, tc := range testCases {
+		e := &config.Endpoint{
+			Retry:    &config.Retry{Priorities: tc.priorities},
+			Backends: []*config.Backend{{Target: "127.0.0.1:8000"}, {Target: "127.0.0.1:8001", Priority: tc.priority}},
+		}
+		if _, err := prepareRetryStrategy(e); (err == nil) != tc.ok {
+			t.Errorf("prepareRetryStrategy(%v, %q) = %v, want ok %v", tc.priorities, tc.priority, err, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"golang.org/x/net/http/httpguts"
+)
+
+var errCopyDone = errors.New("hijacked connection copy complete")
+
+// isUpgradeRequest reports whether the request asks for a protocol switch, eg: WebSocket.
+func isUpgradeRequest(req *http.Request) bool {
+	return httpguts.HeaderValuesContainsToken(req.Header["Connection"], "Upgrade") &&
+		req.Header.Get("Upgrade") != ""
+}
+
+// isStreamingGRPC reports whether the request is a gRPC call on a streaming endpoint,
+// whose request and response messages are forwarded as they arrive.
+func isStreamingGRPC(e *config.Endpoint, req *http.Request) bool {
+	return e.Protocol == config.Protocol_GRPC &&
+		e.Streaming != nil && e.Streaming.Enabled &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
+}
+
+// copyStreaming flushes the response after each read from the backend.
+func copyStreaming(w http.ResponseWriter) bodyCopier {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return io.Copy
+	}
+	return func(dst io.Writer, src io.Reader) (int64, error) {
+		return copyBufferWithCallback(dst, src, make([]byte, 32<<10), func(_ int) {
+			flusher.Flush()
+		})
+	}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+type upgradedConn struct {
+	conn     net.Conn
+	brw      *bufio.ReadWriter
+	backConn io.ReadWriteCloser
+}
+
+// hijackUpgrade hijacks the client connection and writes the switching protocols response to the client,
+// hijacked reports whether the connection has been taken over even if it failed afterwards.
+func hijackUpgrade(w http.ResponseWriter, req *http.Request, resp *http.Response) (upgraded *upgradedConn, hijacked bool, err error) {
+	reqUpType := strings.ToLower(req.Header.Get("Upgrade"))
+	respUpType := strings.ToLower(resp.Header.Get("Upgrade"))
+	if reqUpType != respUpType {
+		resp.Body.Close()
+		return nil, false, fmt.Errorf("backend tried to switch protocol %q when %q was requested", respUpType, reqUpType)
+	}
+	backConn, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, false, errors.New("switching protocols response with non-writable body")
+	}
+	conn, brw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		backConn.Close()
+		return nil, false, fmt.Errorf("hijack failed on protocol switch: %w", err)
+	}
+	// clear the deadlines set by the server for the upgrade request
+	_ = conn.SetDeadline(time.Time{})
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	resp.Header = w.Header()
+	resp.Body = nil // only writes the headers, the body is proxied by the copiers
+	if err = resp.Write(brw); err == nil {
+		err = brw.Flush()
+	}
+	if err != nil {
+		conn.Close()
+		backConn.Close()
+		return nil, true, err
+	}
+	return &upgradedConn{conn: conn, brw: brw, backConn: backConn}, true, nil
+}
+
+// serve copies data between the client and the backend until either side is closed,
+// it returns the bytes sent to the client and received from the client.
+func (u *upgradedConn) serve(ctx context.Context) (sent, received int64, err error) {
+	defer u.conn.Close()
+	defer u.backConn.Close()
+
+	backConnCloseCh := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-backConnCloseCh:
+		}
+		u.backConn.Close()
+	}()
+	defer close(backConnCloseCh)
+
+	toClient := &countingWriter{w: u.conn}
+	toBackend := &countingWriter{w: u.backConn}
+	errc := make(chan error, 2)
+	go func() {
+		// the buffered reader may hold data read from the client after the upgrade request
+		_, err := io.Copy(toBackend, u.brw.Reader)
+		errc <- copyDone(err, u.backConn)
+	}()
+	go func() {
+		_, err := io.Copy(toClient, u.backConn)
+		errc <- copyDone(err, u.conn)
+	}()
+	// either side is done, the deferred closes tear down the other side
+	if err = <-errc; errors.Is(err, errCopyDone) {
+		err = nil
+	}
+	return toClient.n.Load(), toBackend.n.Load(), err
+}
+
+// copyDone propagates close write to the destination after the source has reached EOF.
+func copyDone(err error, dst io.Writer) error {
+	if err != nil {
+		return err
+	}
+	if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+		if err := cw.CloseWrite(); err != nil {
+			return err
+		}
+	}
+	return errCopyDone
+}
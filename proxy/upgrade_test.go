@@ -0,0 +1,125 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestUpgrade(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isUpgradeRequest(r) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, brw, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		brw.Flush()
+		io.Copy(conn, brw)
+	}))
+	defer backend.Close()
+
+	c := &config.Gateway{
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/echo",
+			Method:   "GET",
+			Backends: []*config.Backend{{
+				Target: strings.TrimPrefix(backend.URL, "http://"),
+			}},
+		}},
+	}
+	p, err := New(client.NewFactory(nil), func(*config.Middleware) (middleware.MiddlewareV2, error) {
+		return middleware.EmptyMiddleware, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(client.NewBuildContext(c), c); err != nil {
+		t.Fatal(err)
+	}
+	gateway := httptest.NewServer(p)
+	defer gateway.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(gateway.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := io.WriteString(conn, "GET /echo HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("want switching protocols but got: %d", resp.StatusCode)
+	}
+	for _, msg := range []string{"ping", "pong"} {
+		if _, err := io.WriteString(conn, msg); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, len(msg))
+		if _, err := io.ReadFull(br, buf); err != nil {
+			t.Fatal(err)
+		}
+		if string(buf) != msg {
+			t.Fatalf("want %q but got %q", msg, buf)
+		}
+	}
+}
+
+// hijackRecorder hands over the closed connection, so that writing the response fails after the hijack.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.conn, bufio.NewReadWriter(bufio.NewReader(r.conn), bufio.NewWriter(r.conn)), nil
+}
+
+type pipeBody struct {
+	io.Reader
+	io.Writer
+}
+
+func (pipeBody) Close() error { return nil }
+
+func TestHijackUpgradeFailed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/echo", nil)
+	req.Header.Set("Upgrade", "echo")
+	newResp := func(upgrade string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusSwitchingProtocols,
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"Upgrade": {upgrade}},
+			Body:       pipeBody{Reader: strings.NewReader(""), Writer: io.Discard},
+		}
+	}
+	if _, hijacked, err := hijackUpgrade(httptest.NewRecorder(), req, newResp("other")); err == nil || hijacked {
+		t.Fatalf("expected the error before the hijack, got %v %v", hijacked, err)
+	}
+	client, server := net.Pipe()
+	client.Close()
+	w := &hijackRecorder{ResponseRecorder: httptest.NewRecorder(), conn: server}
+	if _, hijacked, err := hijackUpgrade(w, req, newResp("echo")); err == nil || !hijacked {
+		t.Fatalf("expected the error after the hijack, got %v %v", hijacked, err)
+	}
+}
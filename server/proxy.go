@@ -44,32 +44,60 @@ func init() {
 	}
 }
 
+// Option is a proxy server option.
+type Option func(*ProxyServer)
+
+// WithTLS terminates TLS on the server with the certificates in the store.
+func WithTLS(store *CertificateStore) Option {
+	return func(s *ProxyServer) {
+		s.certs = store
+	}
+}
+
 // ProxyServer is a proxy server.
 type ProxyServer struct {
 	*http.Server
+	certs *CertificateStore
 }
 
 // NewProxy new a gateway server.
-func NewProxy(handler http.Handler, addr string) *ProxyServer {
-	return &ProxyServer{
+func NewProxy(handler http.Handler, addr string, opts ...Option) *ProxyServer {
+	h2s := &http2.Server{
+		IdleTimeout:          idleTimeout,
+		MaxConcurrentStreams: math.MaxUint32,
+	}
+	s := &ProxyServer{
 		Server: &http.Server{
-			Addr: addr,
-			Handler: h2c.NewHandler(handler, &http2.Server{
-				IdleTimeout:          idleTimeout,
-				MaxConcurrentStreams: math.MaxUint32,
-			}),
+			Addr:              addr,
+			Handler:           h2c.NewHandler(handler, h2s),
 			ReadTimeout:       readTimeout,
 			ReadHeaderTimeout: readHeaderTimeout,
 			WriteTimeout:      writeTimeout,
 			IdleTimeout:       idleTimeout,
 		},
 	}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.certs != nil {
+		s.TLSConfig = s.certs.TLSConfig()
+		if err := http2.ConfigureServer(s.Server, h2s); err != nil {
+			log.Fatalf("failed to configure http2 server: %v", err)
+		}
+	}
+	return s
 }
 
 // Start the server.
 func (s *ProxyServer) Start(ctx context.Context) error {
-	log.Infof("proxy listening on %s", s.Addr)
-	err := s.ListenAndServe()
+	var err error
+	if s.certs != nil {
+		log.Infof("proxy listening on %s with tls", s.Addr)
+		err = s.ListenAndServeTLS("", "")
+	} else {
+		log.Infof("proxy listening on %s", s.Addr)
+		err = s.ListenAndServe()
+	}
 	if errors.Is(err, http.ErrServerClosed) {
 		return nil
 	}
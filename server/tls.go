@@ -0,0 +1,190 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var errNoCertificate = errors.New("no certificate is configured for the listening server")
+
+// certificates is an immutable snapshot of the server certificates.
+type certificates struct {
+	byHost       map[string]*tls.Certificate
+	defaultCert  *tls.Certificate
+	minVersion   uint16
+	disableHTTP2 bool
+	clientAuth   tls.ClientAuthType
+	clientCAs    *x509.CertPool
+}
+
+// CertificateStore serves the certificates of the listening server by SNI,
+// it could be updated on config reload without restarting the server.
+type CertificateStore struct {
+	certs atomic.Pointer[certificates]
+}
+
+// NewCertificateStore new a certificate store.
+func NewCertificateStore() *CertificateStore {
+	s := &CertificateStore{}
+	s.certs.Store(&certificates{minVersion: tls.VersionTLS12})
+	return s
+}
+
+func parseTLSVersion(in string) (uint16, error) {
+	switch in {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	}
+	return 0, fmt.Errorf("unknown tls version: %q", in)
+}
+
+func stripPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// Update rebuilds the certificates from the gateway config,
+// the previous certificates are kept if any certificate is invalid.
+func (s *CertificateStore) Update(c *config.Gateway) error {
+	loaded := make(map[string]*tls.Certificate, len(c.TlsStore))
+	load := func(name string) (*tls.Certificate, error) {
+		if cert, ok := loaded[name]; ok {
+			return cert, nil
+		}
+		v, ok := c.TlsStore[name]
+		if !ok {
+			return nil, fmt.Errorf("tls config %q not found in tls_store", name)
+		}
+		cert, err := tls.X509KeyPair([]byte(v.Cert), []byte(v.Key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls cert: %q: %w", name, err)
+		}
+		loaded[name] = &cert
+		return &cert, nil
+	}
+
+	certs := &certificates{
+		byHost:     make(map[string]*tls.Certificate),
+		minVersion: tls.VersionTLS12,
+	}
+	var err error
+	if serverTLS := c.ServerTls; serverTLS != nil {
+		if certs.minVersion, err = parseTLSVersion(serverTLS.MinVersion); err != nil {
+			return err
+		}
+		certs.disableHTTP2 = serverTLS.DisableHttp2
+		if certs.clientAuth, certs.clientCAs, err = loadClientAuth(c, serverTLS); err != nil {
+			return err
+		}
+		if serverTLS.DefaultTlsConfigName != "" {
+			if certs.defaultCert, err = load(serverTLS.DefaultTlsConfigName); err != nil {
+				return err
+			}
+		}
+	}
+	for _, e := range c.Endpoints {
+		if e.TlsConfigName == "" {
+			continue
+		}
+		if e.Host == "" {
+			log.Warnf("skip tls config %q on endpoint: %s %s without host", e.TlsConfigName, e.Method, e.Path)
+			continue
+		}
+		cert, err := load(e.TlsConfigName)
+		if err != nil {
+			return err
+		}
+		host := strings.ToLower(stripPort(e.Host))
+		if exist, ok := certs.byHost[host]; ok && exist != cert {
+			return fmt.Errorf("conflict tls config on host: %q", host)
+		}
+		certs.byHost[host] = cert
+	}
+	s.certs.Store(certs)
+	return nil
+}
+
+func loadClientAuth(c *config.Gateway, serverTLS *config.ServerTLS) (tls.ClientAuthType, *x509.CertPool, error) {
+	name := serverTLS.ClientCaTlsConfigName
+	if name == "" {
+		if serverTLS.ClientAuth > config.ServerTLS_NO_CLIENT_CERT {
+			return tls.NoClientCert, nil, errors.New("client_ca_tls_config_name is required to verify client certificates")
+		}
+		return tls.NoClientCert, nil, nil
+	}
+	v, ok := c.TlsStore[name]
+	if !ok {
+		return tls.NoClientCert, nil, fmt.Errorf("tls config %q not found in tls_store", name)
+	}
+	clientCAs := x509.NewCertPool()
+	if ok := clientCAs.AppendCertsFromPEM([]byte(v.Cacert)); !ok {
+		return tls.NoClientCert, nil, fmt.Errorf("failed to load tls cacert: %q", name)
+	}
+	switch serverTLS.ClientAuth {
+	case config.ServerTLS_NO_CLIENT_CERT:
+		return tls.NoClientCert, nil, nil
+	case config.ServerTLS_REQUIRE_AND_VERIFY_CLIENT_CERT:
+		return tls.RequireAndVerifyClientCert, clientCAs, nil
+	default:
+		return tls.VerifyClientCertIfGiven, clientCAs, nil
+	}
+}
+
+// GetCertificate selects the certificate by the SNI of the client hello,
+// exact host matches are preferred over wildcard hosts, eg: *.example.com.
+func (s *CertificateStore) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certs := s.certs.Load()
+	name := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+	if name != "" {
+		if cert, ok := certs.byHost[name]; ok {
+			return cert, nil
+		}
+		if i := strings.IndexByte(name, '.'); i > 0 {
+			if cert, ok := certs.byHost["*"+name[i:]]; ok {
+				return cert, nil
+			}
+		}
+	}
+	if certs.defaultCert != nil {
+		return certs.defaultCert, nil
+	}
+	return nil, errNoCertificate
+}
+
+// TLSConfig returns the tls config of the listening server,
+// it's evaluated on each handshake so that the updates take effect on new connections.
+func (s *CertificateStore) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: s.GetCertificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			certs := s.certs.Load()
+			nextProtos := []string{"h2", "http/1.1"}
+			if certs.disableHTTP2 {
+				nextProtos = []string{"http/1.1"}
+			}
+			return &tls.Config{
+				GetCertificate: s.GetCertificate,
+				MinVersion:     certs.minVersion,
+				NextProtos:     nextProtos,
+				ClientAuth:     certs.clientAuth,
+				ClientCAs:      certs.clientCAs,
+			}, nil
+		},
+	}
+}
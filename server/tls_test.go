@@ -0,0 +1,144 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+func newTestTLS(t *testing.T, host string) *config.TLS {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config.TLS{
+		Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Key:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func leafName(t *testing.T, cert *tls.Certificate) string {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestCertificateStore(t *testing.T) {
+	c := &config.Gateway{
+		TlsStore: map[string]*config.TLS{
+			"default":  newTestTLS(t, "default"),
+			"foo":      newTestTLS(t, "foo.example.com"),
+			"wildcard": newTestTLS(t, "*.example.com"),
+		},
+		ServerTls: &config.ServerTLS{DefaultTlsConfigName: "default"},
+		Endpoints: []*config.Endpoint{
+			{Path: "/foo", Host: "foo.example.com:8443", TlsConfigName: "foo"},
+			{Path: "/bar", Host: "*.example.com", TlsConfigName: "wildcard"},
+		},
+	}
+	store := NewCertificateStore()
+	if err := store.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		serverName string
+		want       string
+	}{
+		{serverName: "foo.example.com", want: "foo.example.com"},
+		{serverName: "FOO.example.com.", want: "foo.example.com"},
+		{serverName: "bar.example.com", want: "*.example.com"},
+		{serverName: "example.org", want: "default"},
+		{serverName: "", want: "default"},
+	}
+	for _, testCase := range testCases {
+		cert, err := store.GetCertificate(&tls.ClientHelloInfo{ServerName: testCase.serverName})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := leafName(t, cert); got != testCase.want {
+			t.Errorf("GetCertificate(%q) = %q, want %q", testCase.serverName, got, testCase.want)
+		}
+	}
+
+	// the previous certificates are kept on invalid config
+	c.ServerTls.DefaultTlsConfigName = "unknown"
+	if err := store.Update(c); err == nil {
+		t.Fatal("expected error on unknown tls config")
+	}
+	cert, err := store.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := leafName(t, cert); got != "default" {
+		t.Errorf("expected previous default certificate, got %q", got)
+	}
+
+	c.ServerTls = nil
+	if err := store.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetCertificate(&tls.ClientHelloInfo{ServerName: "example.org"}); err == nil {
+		t.Error("expected error without default certificate")
+	}
+}
+
+func TestLoadClientAuth(t *testing.T) {
+	c := &config.Gateway{
+		TlsStore: map[string]*config.TLS{
+			"ca":      {Cacert: newTestTLS(t, "ca").Cert},
+			"invalid": {Cacert: "invalid"},
+		},
+	}
+	testCases := []struct {
+		name       string
+		clientAuth config.ServerTLS_ClientAuth
+		want       tls.ClientAuthType
+		pool       bool
+		err        bool
+	}{
+		{want: tls.NoClientCert},
+		{clientAuth: config.ServerTLS_VERIFY_CLIENT_CERT_IF_GIVEN, err: true},
+		{name: "unknown", err: true},
+		{name: "invalid", err: true},
+		{name: "ca", want: tls.VerifyClientCertIfGiven, pool: true},
+		{name: "ca", clientAuth: config.ServerTLS_NO_CLIENT_CERT, want: tls.NoClientCert},
+		{name: "ca", clientAuth: config.ServerTLS_VERIFY_CLIENT_CERT_IF_GIVEN, want: tls.VerifyClientCertIfGiven, pool: true},
+		{name: "ca", clientAuth: config.ServerTLS_REQUIRE_AND_VERIFY_CLIENT_CERT, want: tls.RequireAndVerifyClientCert, pool: true},
+	}
+	for _, testCase := range testCases {
+		clientAuth, pool, err := loadClientAuth(c, &config.ServerTLS{ClientCaTlsConfigName: testCase.name, ClientAuth: testCase.clientAuth})
+		if (err != nil) != testCase.err {
+			t.Errorf("%q %s: unexpected error: %v", testCase.name, testCase.clientAuth, err)
+			continue
+		}
+		if clientAuth != testCase.want || (pool != nil) != testCase.pool {
+			t.Errorf("%q %s: want %s with pool %v, got %s with %v", testCase.name, testCase.clientAuth, testCase.want, testCase.pool, clientAuth, pool)
+		}
+	}
+}